@@ -9,6 +9,8 @@ import (
 func server(port string) {
 	http.HandleFunc("/create", internal.CreateRoomHandler)
 	http.HandleFunc("/join", internal.JoinRoomHandler)
+	http.HandleFunc("/reconnect", internal.ReconnectHandler)
+	http.HandleFunc("/spectate", internal.SpectateHandler)
 
 	fmt.Printf("Server running on port %s\n", port)
 	err := http.ListenAndServe(":"+port, nil)
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+	"log"
+	"uno/internal/engine"
+	"uno/models/game"
+)
+
+// timeUp plays p's turn for them once their deadline ran out. The room's
+// turn penalty applies when penalty is set, that is when the turn limit or
+// the time bank ran out; otherwise p only left the turn untouched for too long
+// and the engine makes its conservative move. It must be called with g.mu held.
+func (g *Game) timeUp(p *game.Player, penalty bool) {
+	g.timeouts[p.ID]++
+	g.Network.BroadcastInfoMessage(fmt.Sprintf("%s ran out of time. The server plays their turn.", p.Name))
+	if g.timeouts[p.ID] == g.Room.settings.AFKTakeover {
+		g.Network.BroadcastInfoMessage(fmt.Sprintf("%s seems to be away. The server plays for them until they are back.", p.Name))
+	}
+
+	move := g.AutoMove
+	if penalty {
+		move = g.penaltyMove
+	}
+	for i := 0; i < maxAutoMoves && g.ActivePlayer == p && g.Phase != engine.PhaseGameOver; i++ {
+		from := g.Version
+		events, err := g.State.Apply(p, move(p))
+		if err != nil {
+			log.Printf("Failed to play for %s: %v", p.Name, err)
+			break
+		}
+		if g.applied(from, events) {
+			break
+		}
+	}
+	g.autoPlay()
+}
+
+// afk reports whether the server plays p's seat because they timed out too
+// often in a row. It must be called with g.mu held.
+func (g *Game) afk(p *game.Player) bool {
+	return g.timeouts[p.ID] >= g.Room.settings.AFKTakeover
+}
+
+// playerActed hands p's seat back to them once they send a command.
+func (g *Game) playerActed(p *game.Player) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.afk(p) {
+		g.Network.BroadcastInfoMessage(fmt.Sprintf("%s is back and plays for themselves again.", p.Name))
+		delete(g.timeouts, p.ID)
+		g.updateTurnClock(false)
+		return
+	}
+	delete(g.timeouts, p.ID)
+}
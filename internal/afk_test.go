@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"testing"
+	"time"
+	"uno/models/game"
+)
+
+// expire runs the clock past the active player's deadline until the server
+// has played their turn.
+func expire(t *testing.T, g *Game, clock *fakeClock) {
+	t.Helper()
+	g.mu.Lock()
+	p, deadline := g.ActivePlayer, g.deadline
+	g.mu.Unlock()
+	if deadline.IsZero() {
+		t.Fatalf("the turn of %s is not timed", p.Name)
+	}
+	clock.Advance(deadline.Sub(clock.Now()))
+	// The turn clock may not wait yet when the deadline passes, so the clock
+	// keeps ticking until it has caught up.
+	for start := time.Now(); time.Since(start) < 2*time.Second; time.Sleep(time.Millisecond) {
+		g.mu.Lock()
+		done := g.ActivePlayer != p
+		g.mu.Unlock()
+		if done {
+			return
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+	t.Fatalf("the server did not play the turn of %s", p.Name)
+}
+
+// playUntilTurnOf makes the engine's moves for whoever is active until it is
+// p's turn.
+func playUntilTurnOf(t *testing.T, g *Game, p *game.Player) {
+	t.Helper()
+	for i := 0; i < 20; i++ {
+		g.mu.Lock()
+		active := g.ActivePlayer
+		move := g.AutoMove(active)
+		g.mu.Unlock()
+		if active == p {
+			return
+		}
+		if _, err := g.apply(active, move); err != nil {
+			t.Fatalf("%T for %s: %v", move, active.Name, err)
+		}
+	}
+	t.Fatalf("it never became the turn of %s", p.Name)
+}
+
+func TestAFKTakeover(t *testing.T) {
+	settings := game.DefaultRoomSettings()
+	settings.InactivityTimeout = 10
+	settings.AFKTakeover = 2
+	g, clock := newTimedGame(t, settings)
+
+	g.mu.Lock()
+	away := g.ActivePlayer
+	other := g.Players[0]
+	if other == away {
+		other = g.Players[1]
+	}
+	g.mu.Unlock()
+
+	for i := 1; i <= settings.AFKTakeover; i++ {
+		playUntilTurnOf(t, g, away)
+		expire(t, g, clock)
+		g.mu.Lock()
+		afk := g.afk(away)
+		g.mu.Unlock()
+		if want := i == settings.AFKTakeover; afk != want {
+			t.Fatalf("after %d timeouts afk = %v, want %v", i, afk, want)
+		}
+	}
+
+	// From now on the server plays the seat as soon as the turn gets there.
+	version := g.version()
+	for i := 0; ; i++ {
+		if i == 10 {
+			t.Fatalf("the server never played the turn of %s", away.Name)
+		}
+		g.mu.Lock()
+		move := g.AutoMove(other)
+		g.mu.Unlock()
+		before := version
+		var err error
+		if version, err = g.apply(other, move); err != nil {
+			t.Fatalf("%T for %s: %v", move, other.Name, err)
+		}
+		g.mu.Lock()
+		active := g.ActivePlayer
+		g.mu.Unlock()
+		if active != other {
+			t.Fatalf("the turn was left to %s", active.Name)
+		}
+		if version > before+1 {
+			break
+		}
+	}
+
+	g.HandleCommand([]byte(`{"id": "1", "seq": 1, "type": "SYNC_GAME_STATE", "obj": {"version": 0}}`), away)
+	g.mu.Lock()
+	afk := g.afk(away)
+	g.mu.Unlock()
+	if afk {
+		t.Fatalf("%s is still taken over after sending a command", away.Name)
+	}
+	playUntilTurnOf(t, g, away)
+}
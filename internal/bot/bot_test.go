@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"math/rand"
+	"testing"
+	"uno/models/commands"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/dtos"
+	"uno/models/game"
+)
+
+func newView(hand []game.Card, moves dtos.LegalMovesDTO) dtos.SyncDTO {
+	player := game.NewPlayer("bot")
+	player.ID = "bot"
+	player.AddCards(hand)
+	return dtos.SyncDTO{Player: *player, Moves: moves}
+}
+
+func TestHeuristicPlaysMostValuableCard(t *testing.T) {
+	hand := []game.Card{
+		{ID: 1, Rank: rank.WILD},
+		{ID: 2, Rank: rank.FIVE, Color: color.RED},
+		{ID: 3, Rank: rank.SKIP, Color: color.RED},
+		{ID: 4, Rank: rank.NINE, Color: color.BLUE},
+		{ID: 5, Rank: rank.TWO, Color: color.BLUE},
+		{ID: 6, Rank: rank.ONE, Color: color.BLUE},
+	}
+	tests := []struct {
+		name     string
+		playable []int
+		want     int
+	}{
+		{"action card first", []int{1, 2, 3, 4}, 3},
+		{"highest number", []int{1, 2, 4}, 4},
+		{"wild only when nothing else goes", []int{1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := newView(hand, dtos.LegalMovesDTO{PlayableCards: tt.playable, Draw: true})
+			cmd, ok := NewHeuristic().Decide(view).(*commands.PlayCardCommand)
+			if !ok || cmd.CardID != tt.want {
+				t.Fatalf("Decide = %#v, want card %d played", cmd, tt.want)
+			}
+			if cmd.NewColor != string(color.BLUE) {
+				t.Errorf("new color = %s, want the dominant color blue", cmd.NewColor)
+			}
+		})
+	}
+}
+
+func TestHeuristicKeepsDrawnWild(t *testing.T) {
+	view := newView([]game.Card{{ID: 1, Rank: rank.WILD}}, dtos.LegalMovesDTO{PlayableCards: []int{1}, Pass: true})
+	if cmd, ok := NewHeuristic().Decide(view).(*commands.PassCommand); !ok {
+		t.Errorf("Decide = %#v, want a pass", cmd)
+	}
+}
+
+func TestRandomOnlyPicksLegalMoves(t *testing.T) {
+	hand := []game.Card{
+		{ID: 1, Rank: rank.FIVE, Color: color.RED},
+		{ID: 2, Rank: rank.SIX, Color: color.GREEN},
+	}
+	view := newView(hand, dtos.LegalMovesDTO{PlayableCards: []int{1}, Draw: true})
+	b := NewRandom(rand.New(rand.NewSource(1)))
+	for i := 0; i < 100; i++ {
+		switch cmd := b.Decide(view).(type) {
+		case *commands.PlayCardCommand:
+			if cmd.CardID != 1 {
+				t.Fatalf("played card %d, which is not playable", cmd.CardID)
+			}
+		case *commands.DrawCardComamnd:
+		default:
+			t.Fatalf("Decide = %#v, want a play or a draw", cmd)
+		}
+	}
+}
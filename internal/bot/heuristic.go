@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"uno/models/commands"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/dtos"
+	"uno/models/game"
+)
+
+// Heuristic plays the way a careful human would: it gets rid of its most
+// valuable cards first, holds on to its wild cards until nothing else goes,
+// and always picks the color it holds most of.
+type Heuristic struct{}
+
+func NewHeuristic() *Heuristic {
+	return &Heuristic{}
+}
+
+func (b *Heuristic) Decide(view dtos.SyncDTO) interface{} {
+	moves := view.Moves
+	hand := view.Player.Deck.Cards
+	switch {
+	case moves.CatchUno:
+		return &commands.CatchUnoCommand{}
+	case moves.ChooseColor:
+		return &commands.ChooseColorCommand{Color: string(dominantColor(hand))}
+	case moves.Challenge:
+		return &commands.AcceptDraw4Command{}
+	case moves.SwapHands:
+		// Take the smallest hand at the table.
+		var target *dtos.SeatState
+		for _, seat := range opponents(view) {
+			seat := seat
+			if target == nil || seat.Cards < target.Cards {
+				target = &seat
+			}
+		}
+		if target == nil {
+			return nil
+		}
+		return &commands.SwapHandsCommand{Target: target.ID}
+	}
+
+	if card, ok := bestCard(playableCards(view), dominantColor(hand)); ok {
+		// A drawn wild card is kept for later when the bot may pass.
+		if !(moves.Pass && card.Type() == "action-card-no-color") {
+			return playCard(view, card.ID, dominantColor(hand))
+		}
+	}
+	switch {
+	case moves.Draw:
+		return &commands.DrawCardComamnd{}
+	case moves.Pass:
+		return &commands.PassCommand{}
+	}
+	return nil
+}
+
+// bestCard returns the colored card worth the most points, preferring the
+// dominant color on a tie. Wild cards only come up when nothing else can be
+// played, a wild draw_4 first.
+func bestCard(cards []game.Card, dominant color.Color) (game.Card, bool) {
+	var best game.Card
+	found := false
+	for _, card := range cards {
+		if !found || better(card, best, dominant) {
+			best = card
+			found = true
+		}
+	}
+	return best, found
+}
+
+func better(card, than game.Card, dominant color.Color) bool {
+	cardWild := card.Type() == "action-card-no-color"
+	thanWild := than.Type() == "action-card-no-color"
+	switch {
+	case cardWild != thanWild:
+		return !cardWild
+	case cardWild:
+		return card.Rank == rank.DRAW_4 && than.Rank != rank.DRAW_4
+	case card.Points() != than.Points():
+		return card.Points() > than.Points()
+	}
+	return card.Color == dominant && than.Color != dominant
+}
+
+// dominantColor returns the color the hand holds the most cards of.
+func dominantColor(hand []game.Card) color.Color {
+	counts := make(map[color.Color]int)
+	for _, card := range hand {
+		counts[card.Color]++
+	}
+	best := color.ALLColors[0]
+	for _, c := range color.ALLColors {
+		if counts[c] > counts[best] {
+			best = c
+		}
+	}
+	return best
+}
@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"math/rand"
+	"uno/models/commands"
+	"uno/models/constants/color"
+	"uno/models/dtos"
+)
+
+// Random picks any of its legal moves with equal chance.
+type Random struct {
+	rand *rand.Rand
+}
+
+func NewRandom(r *rand.Rand) *Random {
+	return &Random{rand: r}
+}
+
+func (b *Random) Decide(view dtos.SyncDTO) interface{} {
+	moves := view.Moves
+	switch {
+	case moves.ChooseColor:
+		return &commands.ChooseColorCommand{Color: string(b.color())}
+	case moves.Challenge:
+		if b.rand.Intn(2) == 0 {
+			return &commands.ChallengeDraw4Command{}
+		}
+		return &commands.AcceptDraw4Command{}
+	case moves.SwapHands:
+		seats := opponents(view)
+		if len(seats) == 0 {
+			return nil
+		}
+		return &commands.SwapHandsCommand{Target: seats[b.rand.Intn(len(seats))].ID}
+	}
+
+	cards := playableCards(view)
+	options := len(cards)
+	if moves.Draw || moves.Pass {
+		options++
+	}
+	if options == 0 {
+		return nil
+	}
+	choice := b.rand.Intn(options)
+	switch {
+	case choice < len(cards):
+		return playCard(view, cards[choice].ID, b.color())
+	case moves.Draw:
+		return &commands.DrawCardComamnd{}
+	default:
+		return &commands.PassCommand{}
+	}
+}
+
+func (b *Random) color() color.Color {
+	return color.ALLColors[b.rand.Intn(len(color.ALLColors))]
+}
@@ -0,0 +1,69 @@
+// Package bot holds the strategies that play the seats of bot players.
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"uno/models/commands"
+	"uno/models/constants/color"
+	"uno/models/dtos"
+	"uno/models/game"
+)
+
+// Strategy decides what a bot seat does. It gets the same private view a
+// human player is sent, with the legal moves in view.Moves, and returns one
+// of the command structs of models/commands, or nil when it has nothing to do.
+type Strategy interface {
+	Decide(view dtos.SyncDTO) interface{}
+}
+
+// ErrUnknownStrategy is returned for a strategy name that is not registered.
+var ErrUnknownStrategy = errors.New("unknown bot strategy")
+
+var strategies = map[string]func(r *rand.Rand) Strategy{
+	"random":    func(r *rand.Rand) Strategy { return NewRandom(r) },
+	"heuristic": func(r *rand.Rand) Strategy { return NewHeuristic() },
+}
+
+// New returns the strategy registered under name. Random choices are drawn
+// from r.
+func New(name string, r *rand.Rand) (Strategy, error) {
+	newStrategy, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, name)
+	}
+	return newStrategy(r), nil
+}
+
+// playCard plays the card with id from the hand, calling UNO when it is the
+// second to last one.
+func playCard(view dtos.SyncDTO, id int, newColor color.Color) *commands.PlayCardCommand {
+	return &commands.PlayCardCommand{
+		CardID:   id,
+		NewColor: string(newColor),
+		CallUno:  view.Player.Deck.NumberOfCards() == 2,
+	}
+}
+
+// playableCards returns the cards of the hand that may be played.
+func playableCards(view dtos.SyncDTO) []game.Card {
+	var cards []game.Card
+	for _, id := range view.Moves.PlayableCards {
+		if index := view.Player.Deck.IndexOf(id); index >= 0 {
+			cards = append(cards, view.Player.Deck.Cards[index])
+		}
+	}
+	return cards
+}
+
+// opponents returns every seat but the bot's own.
+func opponents(view dtos.SyncDTO) []dtos.SeatState {
+	var seats []dtos.SeatState
+	for _, seat := range view.Game.Seats {
+		if seat.ID != view.Player.ID {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
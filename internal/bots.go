@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"uno/internal/bot"
+	"uno/internal/engine"
+	"uno/models/game"
+)
+
+var (
+	errNotHost     = errors.New("only the host can do this")
+	errRoomFull    = errors.New("the room is full")
+	errGameStarted = errors.New("the game has already started")
+)
+
+// maxAutoMoves caps the moves autoPlay makes in one go, so a table of bots
+// that cannot finish a game does not hold the lock forever.
+const maxAutoMoves = 1000
+
+// addBot lets the host seat a bot before the game starts.
+func (g *Game) addBot(host *game.Player, strategy string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.Players) == 0 || g.Players[0] != host {
+		return errNotHost
+	}
+	return g.seatBot(strategy)
+}
+
+// seatBot seats a new bot played by the named strategy. It must be called
+// with g.mu held.
+func (g *Game) seatBot(strategy string) error {
+	if g.GameStarted {
+		return errGameStarted
+	}
+	if len(g.Players) >= g.Room.maxPlayers {
+		return errRoomFull
+	}
+	// Bots draw from the room seed too, so a seeded game replays the same.
+	s, err := bot.New(strategy, rand.New(rand.NewSource(g.Seed+int64(len(g.Players)))))
+	if err != nil {
+		return err
+	}
+
+	player := game.NewPlayer(g.uniqueName(fmt.Sprintf("%s bot", strategy)))
+	player.ID = newPlayerID()
+	g.State.AddPlayer(player)
+	g.bots[player.ID] = s
+	g.Network.BroadcastInfoMessage(fmt.Sprintf("%s joined the game", player.Name))
+	return nil
+}
+
+// autoPlay makes the moves of bot seats and of players who are away, until it
+// is the turn of a human who is here, and starts that player's turn clock.
+// It must be called with g.mu held.
+func (g *Game) autoPlay() {
+	g.playAutoMoves()
+	g.updateTurnClock(false)
+}
+
+func (g *Game) playAutoMoves() {
+	if g.paused() || !g.humanPresent() {
+		return
+	}
+	for i := 0; i < maxAutoMoves && g.Phase != engine.PhaseGameOver; i++ {
+		p := g.ActivePlayer
+		move := g.autoMove(p)
+		if move == nil {
+			return
+		}
+		from := g.Version
+		events, err := g.State.Apply(p, move)
+		if err != nil {
+			log.Printf("Rejected %T made for %s: %v", move, p.Name, err)
+			if events, err = g.State.Apply(p, g.AutoMove(p)); err != nil {
+				log.Printf("Failed to play for %s: %v", p.Name, err)
+				return
+			}
+		}
+		g.applied(from, events)
+	}
+}
+
+// autoMove returns the move the server makes for p, or nil when p plays
+// for themselves.
+func (g *Game) autoMove(p *game.Player) engine.Move {
+	if strategy, ok := g.bots[p.ID]; ok {
+		if view, ok := g.syncView(p); ok {
+			if move := g.toMove(strategy.Decide(view)); move != nil {
+				return move
+			}
+		}
+		return g.AutoMove(p)
+	}
+	if g.playsFor(p) {
+		return g.AutoMove(p)
+	}
+	return nil
+}
+
+// playsFor reports whether the server makes p's moves: p is a bot, lost
+// their connection or stopped responding.
+func (g *Game) playsFor(p *game.Player) bool {
+	_, isBot := g.bots[p.ID]
+	_, away := g.away[p.ID]
+	return isBot || away || g.afk(p)
+}
+
+// humanPresent reports whether a human is still at the table.
+func (g *Game) humanPresent() bool {
+	for _, p := range g.Players {
+		if !g.playsFor(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"time"
+	"uno/internal/engine"
+	"uno/models/game"
+)
+
+// Clock tells the time to the timers of a room. Tests swap in a fake one to
+// control when deadlines run out.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// updateTurnClock works out when the active player runs out of time after a
+// change to the game, and wakes up runTurnClock to wait for it. newTurn tells
+// whether the change handed the turn to the next player, which charges the
+// time bank of the player who had it. It must be called with g.mu held.
+func (g *Game) updateTurnClock(newTurn bool) {
+	now := g.clock.Now()
+	settings := g.Room.settings
+	p := g.ActivePlayer
+	running := g.GameStarted && p != nil && g.Phase != engine.PhaseGameOver && !g.paused()
+
+	if newTurn || !running {
+		g.stopTurnClock(now)
+	}
+	if running && g.turnStart.IsZero() {
+		g.turnOf, g.turnStart = p, now
+	}
+
+	g.deadline, g.penalty = time.Time{}, false
+	if running && !g.playsFor(p) {
+		if settings.TurnTimer > 0 {
+			g.deadline, g.penalty = g.turnStart.Add(time.Duration(settings.TurnTimer)*time.Second), true
+		}
+		if settings.TimeBank > 0 {
+			if empty := g.turnStart.Add(g.banks[p.ID]); g.deadline.IsZero() || empty.Before(g.deadline) {
+				g.deadline, g.penalty = empty, true
+			}
+		}
+		// Unlike the turn limit, the inactivity timeout starts over whenever
+		// something happens at the table.
+		if settings.InactivityTimeout > 0 {
+			if idle := now.Add(time.Duration(settings.InactivityTimeout) * time.Second); g.deadline.IsZero() || idle.Before(g.deadline) {
+				g.deadline, g.penalty = idle, false
+			}
+		}
+	}
+
+	select {
+	case g.clockReset <- struct{}{}:
+	default:
+	}
+}
+
+// stopTurnClock charges the time spent on the running turn to the time bank
+// of the player who had it.
+func (g *Game) stopTurnClock(now time.Time) {
+	if !g.turnStart.IsZero() && g.Room.settings.TimeBank > 0 {
+		g.banks[g.turnOf.ID] = g.bankLeft(g.turnOf, now)
+	}
+	g.turnOf, g.turnStart = nil, time.Time{}
+}
+
+// bankLeft returns what is left of p's time bank at now.
+func (g *Game) bankLeft(p *game.Player, now time.Time) time.Duration {
+	left := g.banks[p.ID]
+	if p == g.turnOf && !g.turnStart.IsZero() {
+		left -= now.Sub(g.turnStart)
+	}
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// runTurnClock plays the turn of whoever runs out of time, until the game is
+// over. Every room runs one from the start of its game.
+func (g *Game) runTurnClock() {
+	for {
+		g.mu.Lock()
+		over := g.Phase == engine.PhaseGameOver
+		deadline, now := g.deadline, g.clock.Now()
+		g.mu.Unlock()
+		if over {
+			return
+		}
+
+		var expired <-chan time.Time
+		if !deadline.IsZero() {
+			expired = g.clock.After(deadline.Sub(now))
+		}
+		select {
+		case <-g.clockReset:
+		case <-expired:
+			g.deadlineExpired()
+		}
+	}
+}
+
+// deadlineExpired plays the active player's turn if their deadline is still
+// the one that ran out.
+func (g *Game) deadlineExpired() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.deadline.IsZero() || g.clock.Now().Before(g.deadline) {
+		return
+	}
+	g.timeUp(g.ActivePlayer, g.penalty)
+}
+
+// penaltyMove returns the move the server makes for p when their turn limit
+// or time bank ran out.
+func (g *Game) penaltyMove(p *game.Player) engine.Move {
+	if g.Room.settings.TurnPenalty == game.PenaltyDrawAndPass && g.Phase == engine.PhaseDrawn && !g.Rules.ForcedPlay {
+		return engine.Pass{}
+	}
+	return g.AutoMove(p)
+}
+
+// deadlineMillis returns the deadline of the active player in milliseconds
+// since the Unix epoch, or zero when their turn is not timed.
+func (g *Game) deadlineMillis() int64 {
+	if g.deadline.IsZero() {
+		return 0
+	}
+	return g.deadline.UnixMilli()
+}
+
+// timeBanks returns the time left in every player's bank in milliseconds,
+// keyed by player id, or nil when the room plays without a time bank.
+func (g *Game) timeBanks() map[string]int64 {
+	if g.Room.settings.TimeBank == 0 {
+		return nil
+	}
+	now := g.clock.Now()
+	banks := make(map[string]int64, len(g.Players))
+	for _, p := range g.Players {
+		banks[p.ID] = g.bankLeft(p, now).Milliseconds()
+	}
+	return banks
+}
+
+// fillTimeBanks gives every player the full time bank of the room.
+func (g *Game) fillTimeBanks() {
+	for _, p := range g.Players {
+		g.banks[p.ID] = time.Duration(g.Room.settings.TimeBank) * time.Second
+	}
+}
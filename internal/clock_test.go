@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"uno/internal/engine"
+	"uno/models/game"
+)
+
+// fakeClock only moves when the test advances it.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiting = append(waiting, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	c.waiters = waiting
+}
+
+// newTimedGame starts a game between two players whose clock is controlled
+// by the test.
+func newTimedGame(t *testing.T, settings game.RoomSettings) (*Game, *fakeClock) {
+	t.Helper()
+	room := NewRoom(2, settings, 1)
+	t.Cleanup(func() { delete(rooms, room.id) })
+	g := &room.game
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	g.clock = clock
+
+	for i := 1; i <= 2; i++ {
+		player := game.NewPlayer(fmt.Sprintf("player %d", i))
+		player.ID = fmt.Sprintf("p%d", i)
+		g.AddPlayer(player)
+	}
+	g.Start()
+	return g, clock
+}
+
+// waitFor polls cond under the game lock until it holds.
+func waitFor(t *testing.T, g *Game, what string, cond func() bool) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 2*time.Second; time.Sleep(time.Millisecond) {
+		g.mu.Lock()
+		ok := cond()
+		g.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestTurnTimerPenalty(t *testing.T) {
+	settings := game.DefaultRoomSettings()
+	settings.TurnTimer = 10
+	settings.InactivityTimeout = 0
+	g, clock := newTimedGame(t, settings)
+
+	g.mu.Lock()
+	first := g.ActivePlayer
+	start := clock.Now()
+	if got, want := g.deadlineMillis(), start.Add(10*time.Second).UnixMilli(); got != want {
+		t.Errorf("deadline = %d, want %d", got, want)
+	}
+	g.mu.Unlock()
+
+	waitFor(t, g, "the turn clock to wait", func() bool { return clock.timers() > 0 })
+	clock.Advance(9 * time.Second)
+	g.mu.Lock()
+	if g.ActivePlayer != first {
+		t.Fatalf("the turn ended before the deadline")
+	}
+	g.mu.Unlock()
+
+	clock.Advance(time.Second)
+	waitFor(t, g, "the penalty", func() bool { return g.ActivePlayer != first })
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if got, want := first.NumberOfCards(), settings.HandSize+1; got != want {
+		t.Errorf("player who ran out of time holds %d cards, want %d", got, want)
+	}
+	if got, want := g.deadlineMillis(), start.Add(20*time.Second).UnixMilli(); got != want {
+		t.Errorf("deadline of the next turn = %d, want %d", got, want)
+	}
+}
+
+func TestTimeBank(t *testing.T) {
+	settings := game.DefaultRoomSettings()
+	settings.TimeBank = 30
+	settings.InactivityTimeout = 0
+	g, clock := newTimedGame(t, settings)
+
+	g.mu.Lock()
+	first := g.ActivePlayer
+	g.mu.Unlock()
+
+	clock.Advance(12 * time.Second)
+	if _, err := g.apply(first, engine.DrawCard{}); err != nil {
+		t.Fatalf("DrawCard: %v", err)
+	}
+	g.mu.Lock()
+	drawn := g.Phase == engine.PhaseDrawn
+	g.mu.Unlock()
+	if drawn {
+		if _, err := g.apply(first, engine.Pass{}); err != nil {
+			t.Fatalf("Pass: %v", err)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.ActivePlayer == first {
+		t.Fatalf("the turn did not end")
+	}
+	banks := g.timeBanks()
+	if got, want := banks[first.ID], int64(18000); got != want {
+		t.Errorf("bank of the player who moved = %d ms, want %d", got, want)
+	}
+	if got, want := banks[g.ActivePlayer.ID], int64(30000); got != want {
+		t.Errorf("bank of the next player = %d ms, want %d", got, want)
+	}
+	if got, want := g.deadlineMillis(), clock.Now().Add(30*time.Second).UnixMilli(); got != want {
+		t.Errorf("deadline = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"uno/models/constants/color"
+	"uno/models/game"
+)
+
+// AutoMove returns the conservative move the server makes for p when they
+// cannot make it themselves: draw instead of playing from the hand, play the
+// drawn card when it goes, accept a wild draw_4 and pick the color p holds
+// most of. It returns nil when p has nothing to do.
+func (s *State) AutoMove(p *game.Player) Move {
+	if p != s.ActivePlayer {
+		return nil
+	}
+	switch s.Phase {
+	case PhasePlay:
+		return DrawCard{}
+	case PhaseDrawn:
+		return PlayCard{CardID: s.drawnCard.ID, NewColor: dominantColor(p)}
+	case PhaseChooseColor:
+		return ChooseColor{Color: dominantColor(p)}
+	case PhaseChallengeDraw4:
+		return AcceptDraw4{}
+	case PhaseSwapHands:
+		return SwapHands{Target: s.nextPlayer()}
+	}
+	return nil
+}
+
+// dominantColor returns the color p holds the most cards of.
+func dominantColor(p *game.Player) color.Color {
+	counts := make(map[color.Color]int)
+	for _, card := range p.Deck.Cards {
+		counts[card.Color]++
+	}
+	best := color.ALLColors[0]
+	for _, c := range color.ALLColors {
+		if counts[c] > counts[best] {
+			best = c
+		}
+	}
+	return best
+}
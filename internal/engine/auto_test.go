@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestAutoMove(t *testing.T) {
+	tests := []struct {
+		name  string
+		phase Phase
+		want  Move
+	}{
+		{"draws instead of playing", PhasePlay, DrawCard{}},
+		{"plays the drawn card", PhaseDrawn, PlayCard{CardID: 3, NewColor: color.BLUE}},
+		{"picks the dominant color", PhaseChooseColor, ChooseColor{Color: color.BLUE}},
+		{"accepts a wild draw_4", PhaseChallengeDraw4, AcceptDraw4{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			s.Phase = tt.phase
+			player := s.ActivePlayer
+			player.Deck.Cards = []game.Card{
+				{ID: 1, Rank: rank.ONE, Color: color.BLUE},
+				{ID: 2, Rank: rank.TWO, Color: color.BLUE},
+				{ID: 3, Rank: rank.THREE, Color: color.RED},
+			}
+			s.drawnCard = player.Deck.Cards[2]
+
+			if got := s.AutoMove(player); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AutoMove = %#v, want %#v", got, tt.want)
+			}
+			if got := s.AutoMove(s.Players[1]); got != nil {
+				t.Errorf("AutoMove out of turn = %#v, want nil", got)
+			}
+		})
+	}
+}
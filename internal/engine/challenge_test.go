@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestChallengeDraw4(t *testing.T) {
+	tests := []struct {
+		name       string
+		top        game.Card
+		topColor   color.Color // color chosen for a wild top card
+		held       game.Card   // kept by the offender next to the draw_4
+		chosen     color.Color
+		chooseLate bool // the color is picked with a ChooseColor move
+		successful bool
+	}{
+		{"offender held the color in play", game.Card{Rank: rank.FIVE, Color: color.RED}, "", game.Card{ID: 2, Rank: rank.THREE, Color: color.RED}, color.BLUE, false, true},
+		{"offender held only the rank", game.Card{Rank: rank.FIVE, Color: color.RED}, "", game.Card{ID: 2, Rank: rank.FIVE, Color: color.GREEN}, color.BLUE, false, false},
+		{"judged against the color before the play", game.Card{Rank: rank.FIVE, Color: color.RED}, "", game.Card{ID: 2, Rank: rank.THREE, Color: color.GREEN}, color.GREEN, true, false},
+		{"color chosen after a wild", game.Card{Rank: rank.WILD}, color.GREEN, game.Card{ID: 2, Rank: rank.THREE, Color: color.GREEN}, color.BLUE, false, true},
+		{"rank of a wild does not count", game.Card{Rank: rank.WILD}, color.GREEN, game.Card{ID: 2, Rank: rank.THREE, Color: color.RED}, color.RED, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(3, 0, true)
+			if tt.topColor != "" {
+				s.SetTopCard(tt.top, tt.topColor)
+			} else {
+				s.SetTopCard(tt.top)
+			}
+			offender, victim, after := s.Players[0], s.Players[1], s.Players[2]
+			offender.Deck.Cards = []game.Card{{ID: 1, Rank: rank.DRAW_4}, tt.held, {ID: 3, Rank: rank.NINE, Color: color.YELLOW}}
+			victim.Deck.Cards = []game.Card{{ID: 4, Rank: rank.ONE, Color: color.BLUE}, {ID: 5, Rank: rank.NINE, Color: color.BLUE}}
+
+			play := PlayCard{CardID: 1, NewColor: tt.chosen}
+			if tt.chooseLate {
+				play.NewColor = ""
+			}
+			if _, err := s.Apply(offender, play); err != nil {
+				t.Fatalf("PlayCard: %v", err)
+			}
+			if tt.chooseLate {
+				if _, err := s.Apply(offender, ChooseColor{Color: tt.chosen}); err != nil {
+					t.Fatalf("ChooseColor: %v", err)
+				}
+			}
+			if s.Phase != PhaseChallengeDraw4 || s.ActivePlayer != victim {
+				t.Fatalf("phase = %v with %s active, want %v for %s", s.Phase, s.ActivePlayer.Name, PhaseChallengeDraw4, victim.Name)
+			}
+
+			events, err := s.Apply(victim, ChallengeDraw4{})
+			if err != nil {
+				t.Fatalf("ChallengeDraw4: %v", err)
+			}
+			if e, ok := events[0].(Draw4Challenged); !ok || e.Successful != tt.successful {
+				t.Errorf("first event is %#v, want a challenge with Successful = %v", events[0], tt.successful)
+			}
+			if s.TopColor != tt.chosen {
+				t.Errorf("TopColor = %s, want the chosen %s", s.TopColor, tt.chosen)
+			}
+			if s.Phase != PhasePlay {
+				t.Errorf("phase = %v after the challenge, want %v", s.Phase, PhasePlay)
+			}
+
+			wantOffender, wantVictim, wantActive := 2, 2, after
+			if tt.successful {
+				wantOffender, wantActive = 6, victim
+			} else {
+				wantVictim = 8
+			}
+			if got := offender.Deck.NumberOfCards(); got != wantOffender {
+				t.Errorf("offender holds %d cards, want %d", got, wantOffender)
+			}
+			if got := victim.Deck.NumberOfCards(); got != wantVictim {
+				t.Errorf("victim holds %d cards, want %d", got, wantVictim)
+			}
+			if s.ActivePlayer != wantActive {
+				t.Errorf("turn is with %s, want %s", s.ActivePlayer.Name, wantActive.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package engine
+
+import "uno/models/game"
+
+// drawCard draws one card, or keeps drawing until a playable card turns up
+// under game.DrawUntilPlayable. When the last card drawn can be played the
+// player moves to PhaseDrawn; otherwise the turn passes right away.
+func (s *State) drawCard(p *game.Player) ([]Event, error) {
+	if s.PendingDraw > 0 {
+		return s.takePendingDraw(p), nil
+	}
+
+	var cardsDrawn []game.Card
+	var events []Event
+	for {
+		cut, reshuffled := s.drawFromDeck(1)
+		events = append(events, reshuffled...)
+		if len(cut) == 0 {
+			break
+		}
+		cardsDrawn = append(cardsDrawn, cut...)
+		if s.Rules.DrawMode != game.DrawUntilPlayable || s.canPlay(cut[0]) {
+			break
+		}
+	}
+	p.AddCards(cardsDrawn)
+	delete(s.UnoCalled, p)
+	p.Drawn = true
+	events = append(events, CardsDrawn{Player: p, Cards: cardsDrawn})
+
+	if len(cardsDrawn) == 0 || !s.canPlay(cardsDrawn[len(cardsDrawn)-1]) {
+		return append(events, s.NextTurn()...), nil
+	}
+	s.drawnCard = cardsDrawn[len(cardsDrawn)-1]
+	s.Phase = PhaseDrawn
+	return append(events, DrawnCardPlayable{Player: p, Card: s.drawnCard, Forced: s.Rules.ForcedPlay}), nil
+}
+
+// playDrawnCard plays the card drawn during this turn. No other card from the
+// hand may be played after drawing.
+func (s *State) playDrawnCard(p *game.Player, m PlayCard) ([]Event, error) {
+	if _, err := findCard(p, m.CardID); err != nil {
+		return nil, err
+	}
+	if m.CardID != s.drawnCard.ID {
+		return nil, ErrIllegalCard
+	}
+	s.Phase = PhasePlay
+	events, err := s.playCard(p, m)
+	if err != nil {
+		s.Phase = PhaseDrawn
+		return nil, err
+	}
+	return events, nil
+}
+
+// pass keeps the drawn card and ends the turn, unless the room forces it to be played.
+func (s *State) pass(p *game.Player) ([]Event, error) {
+	if s.Rules.ForcedPlay {
+		return nil, ErrMustPlay
+	}
+	s.Phase = PhasePlay
+	return s.NextTurn(), nil
+}
+
+func (s *State) PerformDrawAction(player *game.Player, cardCount int) []Event {
+	cardsDrawn, events := s.drawFromDeck(cardCount)
+	player.AddCards(cardsDrawn)
+	delete(s.UnoCalled, player)
+	return append(events, CardsDrawn{Player: player, Cards: cardsDrawn})
+}
+
+// drawFromDeck takes up to n cards from the draw pile. When the pile runs out
+// the discard pile is shuffled into a new one and drawing carries on. If both
+// piles are empty fewer than n cards are returned, possibly none, and a
+// DeckExhausted event says so.
+func (s *State) drawFromDeck(n int) ([]game.Card, []Event) {
+	var events []Event
+	cards := make([]game.Card, 0, n)
+	for len(cards) < n {
+		if s.GameDeck.NumberOfCards() == 0 {
+			if !s.ShuffleDiscardPileToDeck() {
+				events = append(events, DeckExhausted{Missing: n - len(cards)})
+				break
+			}
+			events = append(events, DeckReshuffled{Cards: s.GameDeck.NumberOfCards()})
+		}
+		cards = append(cards, s.GameDeck.Cut(n-len(cards))...)
+	}
+	return cards, events
+}
@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestDrawFromDeckReshufflesDiscardPile(t *testing.T) {
+	s := NewState(game.Rules{}, 1)
+	top := game.Card{Rank: rank.FIVE, Color: color.RED}
+	s.GameDeck.Cards = []game.Card{{Rank: rank.ONE, Color: color.BLUE}}
+	s.DisposedGameDeck.Cards = []game.Card{
+		{Rank: rank.WILD, Color: color.GREEN},
+		{Rank: rank.TWO, Color: color.YELLOW},
+		top,
+	}
+	s.SetTopCard(top)
+
+	cards, events := s.drawFromDeck(3)
+
+	if len(cards) != 3 {
+		t.Fatalf("drew %d cards, want 3", len(cards))
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want a single DeckReshuffled", len(events))
+	}
+	if _, ok := events[0].(DeckReshuffled); !ok {
+		t.Errorf("event is %T, want DeckReshuffled", events[0])
+	}
+	if got := s.DisposedGameDeck.Cards; len(got) != 1 || got[0] != top {
+		t.Errorf("discard pile is %v, want only the top card %v", got, top)
+	}
+	for _, card := range cards {
+		if card == top {
+			t.Errorf("the top card was shuffled back into the draw pile")
+		}
+		if card.Rank == rank.WILD && card.Color != "" {
+			t.Errorf("wild card kept its color %s", card.Color)
+		}
+	}
+}
+
+func TestDrawFromDeckBothPilesEmpty(t *testing.T) {
+	s := NewState(game.Rules{}, 1)
+	top := game.Card{Rank: rank.FIVE, Color: color.RED}
+	s.GameDeck.Cards = []game.Card{{Rank: rank.ONE, Color: color.BLUE}}
+	s.DisposedGameDeck.Cards = []game.Card{top}
+
+	cards, events := s.drawFromDeck(4)
+
+	if len(cards) != 1 {
+		t.Errorf("drew %d cards, want the single card left", len(cards))
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want a single DeckExhausted", len(events))
+	}
+	if e, ok := events[0].(DeckExhausted); !ok || e.Missing != 3 {
+		t.Errorf("event is %#v, want DeckExhausted{Missing: 3}", events[0])
+	}
+}
+
+func TestDrawCard(t *testing.T) {
+	unplayable := []game.Card{{ID: 10, Rank: rank.ONE, Color: color.BLUE}, {ID: 11, Rank: rank.TWO, Color: color.GREEN}}
+	playable := game.Card{ID: 12, Rank: rank.THREE, Color: color.RED}
+	tests := []struct {
+		name      string
+		mode      game.DrawMode
+		wantDrawn int
+		wantPhase Phase
+	}{
+		{"draw one unplayable", game.DrawOne, 1, PhasePlay},
+		{"draw until playable", game.DrawUntilPlayable, 3, PhaseDrawn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			s.Rules.DrawMode = tt.mode
+			player := s.ActivePlayer
+			player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.NINE, Color: color.YELLOW}}
+			s.GameDeck.Cards = append(append([]game.Card{}, unplayable...), playable, game.Card{ID: 13, Rank: rank.FOUR, Color: color.RED})
+
+			if _, err := s.Apply(player, DrawCard{}); err != nil {
+				t.Fatalf("DrawCard: %v", err)
+			}
+			if got := player.Deck.NumberOfCards(); got != 1+tt.wantDrawn {
+				t.Errorf("player holds %d cards, want %d", got, 1+tt.wantDrawn)
+			}
+			if s.Phase != tt.wantPhase {
+				t.Errorf("phase = %v, want %v", s.Phase, tt.wantPhase)
+			}
+			if wantActive := tt.wantPhase == PhaseDrawn; (s.ActivePlayer == player) != wantActive {
+				t.Errorf("player is still active = %v, want %v", s.ActivePlayer == player, wantActive)
+			}
+		})
+	}
+}
+
+func TestPlayAfterDrawing(t *testing.T) {
+	tests := []struct {
+		name       string
+		forcedPlay bool
+		move       Move
+		want       error
+		wantNext   bool // the turn passed to the next player
+	}{
+		{"pass", false, Pass{}, nil, true},
+		{"play the drawn card", false, PlayCard{CardID: 12}, nil, true},
+		{"play another card", false, PlayCard{CardID: 1}, ErrIllegalCard, false},
+		{"pass under forced play", true, Pass{}, ErrMustPlay, false},
+		{"play under forced play", true, PlayCard{CardID: 12}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			s.Rules.ForcedPlay = tt.forcedPlay
+			player := s.ActivePlayer
+			player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.FIVE, Color: color.GREEN}, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}}
+			drawn := game.Card{ID: 12, Rank: rank.THREE, Color: color.RED}
+			s.GameDeck.Cards = []game.Card{drawn}
+
+			events, err := s.Apply(player, DrawCard{})
+			if err != nil {
+				t.Fatalf("DrawCard: %v", err)
+			}
+			if s.Phase != PhaseDrawn {
+				t.Fatalf("phase = %v after drawing a playable card, want %v", s.Phase, PhaseDrawn)
+			}
+			if e, ok := events[len(events)-1].(DrawnCardPlayable); !ok || e.Card != drawn || e.Forced != tt.forcedPlay {
+				t.Errorf("last event is %#v, want the drawn card playable with Forced = %v", events[len(events)-1], tt.forcedPlay)
+			}
+
+			if _, err := s.Apply(player, tt.move); err != tt.want {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if next := s.ActivePlayer != player; next != tt.wantNext {
+				t.Errorf("turn passed = %v, want %v", next, tt.wantNext)
+			}
+			if !tt.wantNext && s.Phase != PhaseDrawn {
+				t.Errorf("phase = %v after a rejected move, want %v", s.Phase, PhaseDrawn)
+			}
+		})
+	}
+}
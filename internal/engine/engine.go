@@ -0,0 +1,322 @@
+package engine
+
+import (
+	"errors"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+var (
+	ErrGameOver       = errors.New("the game is over")
+	ErrNotYourTurn    = errors.New("it is not your turn")
+	ErrIllegalCard    = errors.New("this card cannot be played")
+	ErrUnknownCard    = errors.New("no card has this id")
+	ErrCardNotOwned   = errors.New("this card is not in your hand")
+	ErrBadColor       = errors.New("invalid color")
+	ErrAlreadyDrawn   = errors.New("a card was already drawn this turn")
+	ErrCannotPass     = errors.New("a card must be drawn before passing")
+	ErrMustPlay       = errors.New("the drawn card has to be played")
+	ErrUnexpectedMove = errors.New("this move is not allowed right now")
+	ErrNothingToCatch = errors.New("nobody can be caught for not calling UNO")
+	ErrBadTarget      = errors.New("invalid target player")
+	ErrJumpInTooLate  = errors.New("another player already jumped in on this card")
+)
+
+// Apply performs the move for player p. The state is updated in place and the
+// events describing the outcome are returned in the order they happened. A
+// rejected move leaves the state untouched and returns one of the Err values.
+// Every accepted move bumps the state's Version.
+func (s *State) Apply(p *game.Player, m Move) ([]Event, error) {
+	events, err := s.apply(p, m)
+	if err == nil {
+		s.Version++
+	}
+	return events, err
+}
+
+func (s *State) apply(p *game.Player, m Move) ([]Event, error) {
+	if s.Phase == PhaseGameOver {
+		return nil, ErrGameOver
+	}
+
+	// UNO can be called and caught out of turn.
+	switch m.(type) {
+	case CallUno:
+		return s.callUno(p)
+	case CatchUno:
+		return s.catchUno(p)
+	}
+
+	if _, ok := m.(PlayCard); p != s.ActivePlayer && !(ok && s.Rules.JumpIn) {
+		return nil, ErrNotYourTurn
+	}
+	offender := s.unoOffender
+	events, err := s.applyTurnMove(p, m)
+	if err == nil && s.unoOffender == offender {
+		// The chance to catch a missing UNO call ends once the next player acts.
+		s.unoOffender = nil
+	}
+	return events, err
+}
+
+func (s *State) applyTurnMove(p *game.Player, m Move) ([]Event, error) {
+	if p != s.ActivePlayer {
+		return s.jumpIn(p, m.(PlayCard))
+	}
+
+	if s.Phase == PhaseChooseColor {
+		if m, ok := m.(ChooseColor); ok {
+			return s.chooseColor(p, m)
+		}
+		return nil, ErrUnexpectedMove
+	}
+	if s.Phase == PhaseChallengeDraw4 {
+		switch m.(type) {
+		case ChallengeDraw4:
+			return s.challengeDraw4(p), nil
+		case AcceptDraw4:
+			return s.acceptDraw4(p), nil
+		}
+		return nil, ErrUnexpectedMove
+	}
+	if s.Phase == PhaseSwapHands {
+		if m, ok := m.(SwapHands); ok {
+			return s.swapHands(p, m)
+		}
+		return nil, ErrUnexpectedMove
+	}
+
+	if s.Phase == PhaseDrawn {
+		switch m := m.(type) {
+		case PlayCard:
+			return s.playDrawnCard(p, m)
+		case Pass:
+			return s.pass(p)
+		case DrawCard:
+			return nil, ErrAlreadyDrawn
+		}
+		return nil, ErrUnexpectedMove
+	}
+
+	switch m := m.(type) {
+	case PlayCard:
+		return s.playCard(p, m)
+	case DrawCard:
+		return s.drawCard(p)
+	case Pass:
+		return nil, ErrCannotPass
+	default:
+		return nil, ErrUnexpectedMove
+	}
+}
+
+func (s *State) playCard(p *game.Player, m PlayCard) ([]Event, error) {
+	index, err := findCard(p, m.CardID)
+	if err != nil {
+		return nil, err
+	}
+	card := p.Deck.Cards[index]
+	if !s.canPlay(card) {
+		return nil, ErrIllegalCard
+	}
+	callUno := m.CallUno && p.Deck.NumberOfCards() == 2
+	s.jumpedIn = false
+
+	if card.Type() == "action-card-no-color" {
+		var chosen color.Color
+		if m.NewColor != "" {
+			parsedColor, err := color.ParseColor(string(m.NewColor))
+			if err != nil {
+				return nil, ErrBadColor
+			}
+			chosen = parsedColor
+		}
+		if card.Rank == rank.DRAW_4 && !s.Rules.Stacking {
+			s.draw4 = &draw4Play{
+				offender: p,
+				hand:     append([]game.Card(nil), p.Deck.Cards...),
+				color:    s.TopColor,
+			}
+		}
+		s.SetTopCard(card, chosen)
+		s.DisposedGameDeck.AddCard(p.Deck.RemoveCard(index))
+		events := []Event{CardPlayed{Player: p, Card: card, Color: chosen}}
+		if callUno {
+			events = append(events, s.declareUno(p))
+		}
+
+		if chosen == "" {
+			s.Phase = PhaseChooseColor
+			return events, nil
+		}
+		return append(events, s.finishWild(card)...), nil
+	}
+
+	s.SetTopCard(card)
+	s.DisposedGameDeck.AddCard(p.Deck.RemoveCard(index))
+	events := []Event{CardPlayed{Player: p, Card: card}}
+	if callUno {
+		events = append(events, s.declareUno(p))
+	}
+
+	skip := false
+	if card.Type() == "action-card" {
+		var effects []Event
+		effects, skip = s.dealwithActionCards(card)
+		events = append(events, effects...)
+	}
+	if s.Rules.SevenO && p.Deck.NumberOfCards() > 0 {
+		switch card.Rank {
+		case rank.SEVEN:
+			// Without an opponent there is nobody to swap with.
+			if len(s.Players) > 1 {
+				s.Phase = PhaseSwapHands
+				return events, nil
+			}
+		case rank.ZERO:
+			events = append(events, s.rotateHands())
+		}
+	}
+	return append(events, s.advanceTurn(skip)...), nil
+}
+
+// findCard returns the position of the card with the given id in p's hand.
+func findCard(p *game.Player, id int) (int, error) {
+	if id < 1 || id > game.DeckSize {
+		return -1, ErrUnknownCard
+	}
+	index := p.Deck.IndexOf(id)
+	if index < 0 {
+		return -1, ErrCardNotOwned
+	}
+	return index, nil
+}
+
+func (s *State) chooseColor(p *game.Player, m ChooseColor) ([]Event, error) {
+	parsedColor, err := color.ParseColor(string(m.Color))
+	if err != nil {
+		return nil, ErrBadColor
+	}
+	s.TopColor = parsedColor
+	s.Phase = PhasePlay
+
+	events := []Event{ColorChosen{Player: p, Color: parsedColor}}
+	if s.openingWild {
+		// The opening wild only needs a color; the first player still takes their turn.
+		s.openingWild = false
+		return events, nil
+	}
+	return append(events, s.finishWild(s.TopCard)...), nil
+}
+
+// finishWild applies the effect of a wild card once its color is known.
+func (s *State) finishWild(card game.Card) []Event {
+	var events []Event
+	if card.Rank == rank.DRAW_4 {
+		if s.Rules.Stacking {
+			events = append(events, s.stackDraw(4))
+		} else if s.ActivePlayer.Deck.NumberOfCards() == 0 {
+			// Going out on a draw_4 still makes the next player draw, which counts for the score.
+			s.draw4 = nil
+			events = append(events, s.PerformDrawAction(s.nextPlayer(), 4)...)
+		} else {
+			// The victim decides between accepting and challenging on their own turn.
+			events = append(events, s.NextTurn()...)
+			if s.Phase != PhaseGameOver {
+				s.Phase = PhaseChallengeDraw4
+				events = append(events, Draw4Pending{Offender: s.draw4.offender, Victim: s.ActivePlayer})
+			}
+			return events
+		}
+	}
+	return append(events, s.NextTurn()...)
+}
+
+// acceptDraw4 makes the victim take the four cards and lose their turn.
+func (s *State) acceptDraw4(victim *game.Player) []Event {
+	s.draw4 = nil
+	s.Phase = PhasePlay
+	events := append(s.PerformDrawAction(victim, 4), TurnSkipped{Player: victim})
+	return append(events, s.NextTurn()...)
+}
+
+// challengeDraw4 settles a challenge. If the offender held a card of the color
+// in play they draw the four cards instead and the victim plays as usual;
+// otherwise the victim draws six cards and loses their turn.
+func (s *State) challengeDraw4(victim *game.Player) []Event {
+	play := s.draw4
+	s.draw4 = nil
+	s.Phase = PhasePlay
+
+	successful := false
+	for _, card := range play.hand {
+		if card.Color == play.color {
+			successful = true
+			break
+		}
+	}
+	events := []Event{Draw4Challenged{Challenger: victim, Offender: play.offender, Successful: successful}}
+
+	if successful {
+		return append(events, s.PerformDrawAction(play.offender, 4)...)
+	}
+	events = append(events, s.PerformDrawAction(victim, 6)...)
+	events = append(events, TurnSkipped{Player: victim})
+	return append(events, s.NextTurn()...)
+}
+
+// stackDraw adds cardCount to the penalty owed by the next player.
+func (s *State) stackDraw(cardCount int) Event {
+	s.PendingDraw += cardCount
+	return DrawStacked{Player: s.ActivePlayer, Total: s.PendingDraw}
+}
+
+// canStack reports whether card may be played on top of a pending draw penalty.
+// Only a draw card of the same rank as the one on top can be stacked.
+func (s *State) canStack(card game.Card) bool {
+	return (card.Rank == rank.DRAW_2 || card.Rank == rank.DRAW_4) && card.IsSameRank(s.TopCard)
+}
+
+func (s *State) hasStackableCard(p *game.Player) bool {
+	for _, card := range p.Deck.Cards {
+		if s.canStack(card) {
+			return true
+		}
+	}
+	return false
+}
+
+// takePendingDraw makes p draw the whole stacked penalty and lose their turn.
+func (s *State) takePendingDraw(p *game.Player) []Event {
+	total := s.PendingDraw
+	s.PendingDraw = 0
+	events := append(s.PerformDrawAction(p, total), TurnSkipped{Player: p})
+	return append(events, s.NextTurn()...)
+}
+
+// callUno records that p called UNO. It has to be done while holding two
+// cards, before or while playing the second to last one.
+func (s *State) callUno(p *game.Player) ([]Event, error) {
+	if p.Deck.NumberOfCards() != 2 {
+		return nil, ErrUnexpectedMove
+	}
+	return []Event{s.declareUno(p)}, nil
+}
+
+func (s *State) declareUno(p *game.Player) Event {
+	s.UnoCalled[p] = true
+	return UnoCalled{Player: p}
+}
+
+// catchUno makes the player who went down to one card without calling UNO
+// draw two cards.
+func (s *State) catchUno(catcher *game.Player) ([]Event, error) {
+	offender := s.unoOffender
+	if offender == nil || offender == catcher {
+		return nil, ErrNothingToCatch
+	}
+	s.unoOffender = nil
+	events := []Event{UnoCaught{Catcher: catcher, Offender: offender}}
+	return append(events, s.PerformDrawAction(offender, 2)...), nil
+}
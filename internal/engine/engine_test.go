@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestApplyVersion(t *testing.T) {
+	s := newTurnTestState(2, 0, true)
+	player := s.ActivePlayer
+	player.Deck.Cards = []game.Card{
+		{ID: 1, Rank: rank.THREE, Color: color.RED},
+		{ID: 2, Rank: rank.ONE, Color: color.GREEN},
+	}
+	version := s.Version
+
+	if _, err := s.Apply(player, PlayCard{CardID: 2}); err == nil {
+		t.Fatalf("playing a green 1 on a red 5 was accepted")
+	}
+	if s.Version != version {
+		t.Errorf("Version = %d after a rejected move, want %d", s.Version, version)
+	}
+
+	if _, err := s.Apply(player, PlayCard{CardID: 1}); err != nil {
+		t.Fatalf("PlayCard: %v", err)
+	}
+	if s.Version != version+1 {
+		t.Errorf("Version = %d after an accepted move, want %d", s.Version, version+1)
+	}
+}
+
+// tableView is what a move can change on the table, copied so a later move
+// cannot alter it.
+type tableView struct {
+	Hands       [][]game.Card
+	TopCard     game.Card
+	TopColor    color.Color
+	CurrentTurn int
+	Phase       Phase
+	PendingDraw int
+	DrawPile    int
+	DiscardPile int
+	Version     int
+}
+
+func viewTable(s *State) tableView {
+	v := tableView{
+		TopCard:     s.TopCard,
+		TopColor:    s.TopColor,
+		CurrentTurn: s.CurrentTurn,
+		Phase:       s.Phase,
+		PendingDraw: s.PendingDraw,
+		DrawPile:    s.GameDeck.NumberOfCards(),
+		DiscardPile: s.DisposedGameDeck.NumberOfCards(),
+		Version:     s.Version,
+	}
+	for _, p := range s.Players {
+		v.Hands = append(v.Hands, append([]game.Card{}, p.Deck.Cards...))
+	}
+	return v
+}
+
+func TestApplyPlayCard(t *testing.T) {
+	s := newTurnTestState(3, 0, true)
+	player := s.ActivePlayer
+	card := game.Card{ID: 1, Rank: rank.THREE, Color: color.RED}
+	player.Deck.Cards = []game.Card{card, {ID: 2, Rank: rank.ONE, Color: color.GREEN}, {ID: 3, Rank: rank.TWO, Color: color.GREEN}}
+	discarded := s.DisposedGameDeck.NumberOfCards()
+
+	events, err := s.Apply(player, PlayCard{CardID: 1})
+	if err != nil {
+		t.Fatalf("PlayCard: %v", err)
+	}
+	if s.TopCard != card || s.TopColor != color.RED {
+		t.Errorf("top card = %v %s, want %v", s.TopCard, s.TopColor, card)
+	}
+	if player.Deck.IndexOf(1) >= 0 {
+		t.Errorf("the played card is still in the hand")
+	}
+	if got := s.DisposedGameDeck.NumberOfCards(); got != discarded+1 {
+		t.Errorf("discard pile holds %d cards, want %d", got, discarded+1)
+	}
+	if s.CurrentTurn != 1 {
+		t.Errorf("CurrentTurn = %d, want 1", s.CurrentTurn)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want CardPlayed and TurnChanged", len(events))
+	}
+	if e, ok := events[0].(CardPlayed); !ok || e.Player != player || e.Card != card {
+		t.Errorf("first event is %#v, want the card played", events[0])
+	}
+	if e, ok := events[1].(TurnChanged); !ok || e.Player != s.Players[1] {
+		t.Errorf("second event is %#v, want the turn handed to seat 1", events[1])
+	}
+}
+
+func TestApplyDrawCard(t *testing.T) {
+	s := newTurnTestState(2, 0, true)
+	player := s.ActivePlayer
+	player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.ONE, Color: color.GREEN}}
+	drawn := game.Card{ID: 2, Rank: rank.THREE, Color: color.BLUE}
+	s.GameDeck.Cards = []game.Card{drawn}
+
+	events, err := s.Apply(player, DrawCard{})
+	if err != nil {
+		t.Fatalf("DrawCard: %v", err)
+	}
+	if player.Deck.IndexOf(drawn.ID) < 0 {
+		t.Errorf("the drawn card is not in the hand")
+	}
+	if s.ActivePlayer != s.Players[1] || s.Phase != PhasePlay {
+		t.Errorf("turn stayed with the player who drew an unplayable card")
+	}
+	if e, ok := events[0].(CardsDrawn); !ok || len(e.Cards) != 1 || e.Cards[0] != drawn {
+		t.Errorf("first event is %#v, want the card drawn", events[0])
+	}
+}
+
+func TestApplyChooseColor(t *testing.T) {
+	s := newTurnTestState(2, 0, true)
+	player := s.ActivePlayer
+	player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.WILD}, {ID: 2, Rank: rank.ONE, Color: color.GREEN}}
+
+	if _, err := s.Apply(player, PlayCard{CardID: 1}); err != nil {
+		t.Fatalf("PlayCard: %v", err)
+	}
+	if s.Phase != PhaseChooseColor || s.ActivePlayer != player {
+		t.Fatalf("phase = %v, want %v for the player of the wild", s.Phase, PhaseChooseColor)
+	}
+	if _, err := s.Apply(player, ChooseColor{Color: "purple"}); err != ErrBadColor {
+		t.Errorf("choosing purple: err = %v, want %v", err, ErrBadColor)
+	}
+	if _, err := s.Apply(player, ChooseColor{Color: color.GREEN}); err != nil {
+		t.Fatalf("ChooseColor: %v", err)
+	}
+	if s.TopColor != color.GREEN {
+		t.Errorf("TopColor = %s, want %s", s.TopColor, color.GREEN)
+	}
+	if s.Phase != PhasePlay || s.ActivePlayer != s.Players[1] {
+		t.Errorf("the turn did not pass once the color was chosen")
+	}
+}
+
+func TestApplyRejectedMoveLeavesStateUnchanged(t *testing.T) {
+	tests := []struct {
+		name  string
+		other bool // the move is made by the player who is not active
+		move  Move
+		phase Phase
+		want  error
+	}{
+		{"not your turn", true, DrawCard{}, PhasePlay, ErrNotYourTurn},
+		{"card that does not go", false, PlayCard{CardID: 2}, PhasePlay, ErrIllegalCard},
+		{"card of another player", false, PlayCard{CardID: 3}, PhasePlay, ErrCardNotOwned},
+		{"wild with a bad color", false, PlayCard{CardID: 4, NewColor: "purple"}, PhasePlay, ErrBadColor},
+		{"pass before drawing", false, Pass{}, PhasePlay, ErrCannotPass},
+		{"color without a wild", false, ChooseColor{Color: color.BLUE}, PhasePlay, ErrUnexpectedMove},
+		{"draw twice", false, DrawCard{}, PhaseDrawn, ErrAlreadyDrawn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			s.Phase = tt.phase
+			player := s.ActivePlayer
+			player.Deck.Cards = []game.Card{
+				{ID: 1, Rank: rank.THREE, Color: color.RED},
+				{ID: 2, Rank: rank.ONE, Color: color.GREEN},
+				{ID: 4, Rank: rank.WILD},
+			}
+			s.Players[1].Deck.Cards = []game.Card{{ID: 3, Rank: rank.FIVE, Color: color.RED}}
+			if tt.other {
+				player = s.Players[1]
+			}
+			before := viewTable(s)
+
+			if _, err := s.Apply(player, tt.move); err != tt.want {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if after := viewTable(s); !reflect.DeepEqual(after, before) {
+				t.Errorf("state changed from %+v to %+v", before, after)
+			}
+		})
+	}
+}
+
+func TestPlayCardIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    int
+		phase Phase
+		want  error
+	}{
+		{"id 0", 0, PhasePlay, ErrUnknownCard},
+		{"negative id", -1, PhasePlay, ErrUnknownCard},
+		{"id above the deck", game.DeckSize + 1, PhasePlay, ErrUnknownCard},
+		{"card of another player", 3, PhasePlay, ErrCardNotOwned},
+		{"unknown id after drawing", 0, PhaseDrawn, ErrUnknownCard},
+		{"card of another player after drawing", 3, PhaseDrawn, ErrCardNotOwned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			s.Phase = tt.phase
+			player := s.ActivePlayer
+			player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.THREE, Color: color.RED}}
+			s.drawnCard = player.Deck.Cards[0]
+			s.Players[1].Deck.Cards = []game.Card{{ID: 3, Rank: rank.FIVE, Color: color.RED}}
+
+			if _, err := s.Apply(player, PlayCard{CardID: tt.id}); err != tt.want {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"uno/models/constants/color"
+	"uno/models/game"
+)
+
+// Event records something that happened at the table as the result of a move.
+type Event interface {
+	isEvent()
+}
+
+// CardPlayed is emitted when a card lands on the discard pile. Color is the
+// color chosen for a wild card, or empty when none was chosen yet.
+type CardPlayed struct {
+	Player *game.Player
+	Card   game.Card
+	Color  color.Color
+}
+
+// JumpedIn is emitted when a player takes the turn by playing a card
+// identical to the top card out of turn. The CardPlayed event follows it.
+type JumpedIn struct {
+	Player *game.Player
+	Card   game.Card
+}
+
+// ColorChosen is emitted when a player picks the color after a wild card.
+type ColorChosen struct {
+	Player *game.Player
+	Color  color.Color
+}
+
+// CardsDrawn is emitted when a player takes cards from the draw pile.
+type CardsDrawn struct {
+	Player *game.Player
+	Cards  []game.Card
+}
+
+// DrawnCardPlayable is emitted when the card a player drew can be played.
+// Unless Forced is set they may also keep it and pass.
+type DrawnCardPlayable struct {
+	Player *game.Player
+	Card   game.Card
+	Forced bool
+}
+
+// DeckReshuffled is emitted when the discard pile becomes the new draw pile.
+type DeckReshuffled struct {
+	Cards int
+}
+
+// DeckExhausted is emitted when both piles are empty and Missing cards could
+// not be drawn. The game carries on without them.
+type DeckExhausted struct {
+	Missing int
+}
+
+// TurnSkipped is emitted when a player loses their turn.
+type TurnSkipped struct {
+	Player *game.Player
+}
+
+// DrawStacked is emitted when a draw card is added to the running penalty.
+// Total is the number of cards the next player will have to draw.
+type DrawStacked struct {
+	Player *game.Player
+	Total  int
+}
+
+// Draw4Pending is emitted when the victim of a wild draw_4 has to decide
+// whether to accept or challenge it.
+type Draw4Pending struct {
+	Offender *game.Player
+	Victim   *game.Player
+}
+
+// Draw4Challenged is emitted when a wild draw_4 challenge is settled.
+// Successful is true when the offender could have played another card.
+type Draw4Challenged struct {
+	Challenger *game.Player
+	Offender   *game.Player
+	Successful bool
+}
+
+// HandsSwapped is emitted when a 7 makes two players swap hands.
+type HandsSwapped struct {
+	Player *game.Player
+	Target *game.Player
+}
+
+// HandsRotated is emitted when a 0 passes every hand along the direction of play.
+type HandsRotated struct {
+	Direction bool
+}
+
+// DirectionReversed is emitted when the play direction flips.
+type DirectionReversed struct {
+	Direction bool
+}
+
+// LastCard is emitted when a player ends their turn holding a single card.
+// Called tells whether they called UNO in time.
+type LastCard struct {
+	Player *game.Player
+	Called bool
+}
+
+// UnoCalled is emitted when a player calls UNO.
+type UnoCalled struct {
+	Player *game.Player
+}
+
+// UnoCaught is emitted when a player is caught for not calling UNO.
+type UnoCaught struct {
+	Catcher  *game.Player
+	Offender *game.Player
+}
+
+// TurnChanged is emitted when a new player becomes active.
+type TurnChanged struct {
+	Player *game.Player
+}
+
+// RoundWon is emitted when a player empties their hand during a match.
+// Points is what the round earned them.
+type RoundWon struct {
+	Player *game.Player
+	Points int
+	Round  int
+}
+
+// RoundStarted is emitted when a new round of a match has been dealt.
+type RoundStarted struct {
+	Round int
+}
+
+// PlayerWon is emitted when a player wins the game, or the whole match when
+// several rounds are played.
+type PlayerWon struct {
+	Player *game.Player
+}
+
+func (CardPlayed) isEvent()        {}
+func (JumpedIn) isEvent()          {}
+func (ColorChosen) isEvent()       {}
+func (CardsDrawn) isEvent()        {}
+func (DrawnCardPlayable) isEvent() {}
+func (DeckReshuffled) isEvent()    {}
+func (DeckExhausted) isEvent()     {}
+func (TurnSkipped) isEvent()       {}
+func (DrawStacked) isEvent()       {}
+func (Draw4Pending) isEvent()      {}
+func (Draw4Challenged) isEvent()   {}
+func (HandsSwapped) isEvent()      {}
+func (HandsRotated) isEvent()      {}
+func (DirectionReversed) isEvent() {}
+func (LastCard) isEvent()          {}
+func (UnoCalled) isEvent()         {}
+func (UnoCaught) isEvent()         {}
+func (TurnChanged) isEvent()       {}
+func (RoundWon) isEvent()          {}
+func (RoundStarted) isEvent()      {}
+func (PlayerWon) isEvent()         {}
@@ -0,0 +1,55 @@
+package engine
+
+import "uno/models/game"
+
+// jumpIn lets p play a card identical in rank and color to the top card while
+// it is not their turn. The turn then continues from p.
+//
+// Moves are applied one at a time and the first one applied wins. A jump-in
+// that races the active player's own move is settled that way: if the active
+// player moves first the top card changes and the jump-in no longer matches;
+// if the jump-in goes first the turn has moved on. Either way the loser gets
+// ErrNotYourTurn.
+//
+// Only two copies of each colored card exist and one of them is the top card,
+// so nobody can normally jump in on a jump-in. The other copy can only come
+// back through a reshuffle, for instance when a jumped-in draw_2 makes the
+// next player draw from a reshuffled pile. Such a card is rejected with
+// ErrJumpInTooLate: a card played out of turn cannot be jumped on again.
+func (s *State) jumpIn(p *game.Player, m PlayCard) ([]Event, error) {
+	if s.Phase != PhasePlay || s.PendingDraw > 0 {
+		return nil, ErrNotYourTurn
+	}
+	index, err := findCard(p, m.CardID)
+	if err != nil {
+		return nil, err
+	}
+	card := p.Deck.Cards[index]
+	if !s.canJumpIn(card) {
+		return nil, ErrNotYourTurn
+	}
+	if s.jumpedIn {
+		return nil, ErrJumpInTooLate
+	}
+
+	seat := s.seatOf(p)
+	if seat < 0 {
+		return nil, ErrNotYourTurn
+	}
+
+	s.ActivePlayer.Drawn = false
+	s.SetActivePlayer(seat)
+	events := []Event{JumpedIn{Player: p, Card: card}}
+
+	played, err := s.playCard(p, m)
+	if err != nil {
+		return nil, err
+	}
+	s.jumpedIn = true
+	return append(events, played...), nil
+}
+
+// canJumpIn reports whether card is identical to the top card.
+func (s *State) canJumpIn(card game.Card) bool {
+	return card.Color != "" && card.IsSameColor(s.TopCard) && card.IsSameRank(s.TopCard)
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestJumpIn(t *testing.T) {
+	s := newTurnTestState(4, 0, true)
+	s.Rules.JumpIn = true
+	jumper := s.Players[2]
+	card := game.Card{ID: 1, Rank: rank.FIVE, Color: color.RED}
+	jumper.Deck.Cards = []game.Card{card, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}, {ID: 3, Rank: rank.ONE, Color: color.BLUE}}
+
+	events, err := s.Apply(jumper, PlayCard{CardID: 1})
+	if err != nil {
+		t.Fatalf("jump-in: %v", err)
+	}
+	if e, ok := events[0].(JumpedIn); !ok || e.Player != jumper || e.Card != card {
+		t.Errorf("first event is %#v, want %s jumping in", events[0], jumper.Name)
+	}
+	if s.CurrentTurn != 3 {
+		t.Errorf("CurrentTurn = %d, want 3, the seat after the jumper", s.CurrentTurn)
+	}
+	if s.TopCard != card {
+		t.Errorf("top card = %v, want the jumped-in %v", s.TopCard, card)
+	}
+}
+
+func TestJumpInRace(t *testing.T) {
+	tests := []struct {
+		name        string
+		jumperFirst bool
+	}{
+		{"jump-in applied first", true},
+		{"active player applied first", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(4, 0, true)
+			s.Rules.JumpIn = true
+			active, jumper := s.Players[0], s.Players[2]
+			active.Deck.Cards = []game.Card{{ID: 1, Rank: rank.FIVE, Color: color.BLUE}, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}}
+			jumper.Deck.Cards = []game.Card{{ID: 3, Rank: rank.FIVE, Color: color.RED}, {ID: 4, Rank: rank.ONE, Color: color.YELLOW}}
+			moves := []struct {
+				p *game.Player
+				m Move
+			}{
+				{active, PlayCard{CardID: 1}},
+				{jumper, PlayCard{CardID: 3}},
+			}
+			if tt.jumperFirst {
+				moves[0], moves[1] = moves[1], moves[0]
+			}
+
+			if _, err := s.Apply(moves[0].p, moves[0].m); err != nil {
+				t.Fatalf("first move: %v", err)
+			}
+			if _, err := s.Apply(moves[1].p, moves[1].m); err != ErrNotYourTurn {
+				t.Errorf("second move: err = %v, want %v", err, ErrNotYourTurn)
+			}
+		})
+	}
+}
+
+func TestJumpInOnJumpIn(t *testing.T) {
+	s := newTurnTestState(4, 0, true)
+	s.Rules.JumpIn = true
+	first := game.Card{ID: 10, Rank: rank.DRAW_2, Color: color.RED}
+	s.SetTopCard(first)
+	s.GameDeck.Cards = nil
+	s.DisposedGameDeck.Cards = []game.Card{first}
+	jumper, victim := s.Players[2], s.Players[3]
+	jumper.Deck.Cards = []game.Card{{ID: 11, Rank: rank.DRAW_2, Color: color.RED}, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}}
+	victim.Deck.Cards = []game.Card{{ID: 3, Rank: rank.ONE, Color: color.BLUE}}
+
+	if _, err := s.Apply(jumper, PlayCard{CardID: 11}); err != nil {
+		t.Fatalf("jump-in: %v", err)
+	}
+	// The draw_2 reshuffled the first copy into the pile and the victim drew it.
+	if victim.Deck.IndexOf(first.ID) < 0 {
+		t.Fatalf("victim holds %v, want the other copy of the draw_2", victim.Deck.Cards)
+	}
+	if _, err := s.Apply(victim, PlayCard{CardID: first.ID}); err != ErrJumpInTooLate {
+		t.Errorf("jumping in on a jump-in: err = %v, want %v", err, ErrJumpInTooLate)
+	}
+}
@@ -0,0 +1,57 @@
+package engine
+
+import "uno/models/game"
+
+// LegalMoves lists the moves Apply accepts from a player in the current state.
+type LegalMoves struct {
+	Cards       []int // ids of the cards that can be played
+	Draw        bool
+	Pass        bool
+	ChooseColor bool
+	Challenge   bool // ChallengeDraw4 or AcceptDraw4
+	SwapHands   bool
+	CallUno     bool
+	CatchUno    bool
+}
+
+// Legal returns the moves p may make right now. It follows the same rules as
+// Apply, so clients never have to work them out for themselves.
+func (s *State) Legal(p *game.Player) LegalMoves {
+	moves := LegalMoves{Cards: []int{}}
+	if s.Phase == PhaseGameOver || s.seatOf(p) < 0 {
+		return moves
+	}
+	moves.CallUno = p.Deck.NumberOfCards() == 2
+	moves.CatchUno = s.unoOffender != nil && s.unoOffender != p
+
+	if p != s.ActivePlayer {
+		if s.Rules.JumpIn && s.Phase == PhasePlay && s.PendingDraw == 0 && !s.jumpedIn {
+			for _, card := range p.Deck.Cards {
+				if s.canJumpIn(card) {
+					moves.Cards = append(moves.Cards, card.ID)
+				}
+			}
+		}
+		return moves
+	}
+
+	switch s.Phase {
+	case PhasePlay:
+		moves.Draw = true
+		for _, card := range p.Deck.Cards {
+			if s.canPlay(card) {
+				moves.Cards = append(moves.Cards, card.ID)
+			}
+		}
+	case PhaseDrawn:
+		moves.Cards = append(moves.Cards, s.drawnCard.ID)
+		moves.Pass = !s.Rules.ForcedPlay
+	case PhaseChooseColor:
+		moves.ChooseColor = true
+	case PhaseChallengeDraw4:
+		moves.Challenge = true
+	case PhaseSwapHands:
+		moves.SwapHands = true
+	}
+	return moves
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestLegalPlayableCards(t *testing.T) {
+	hand := []game.Card{
+		{ID: 1, Rank: rank.THREE, Color: color.RED},
+		{ID: 2, Rank: rank.FIVE, Color: color.GREEN},
+		{ID: 3, Rank: rank.DRAW_2, Color: color.BLUE},
+		{ID: 4, Rank: rank.WILD},
+	}
+	tests := []struct {
+		name        string
+		top         game.Card
+		topColor    color.Color
+		pendingDraw int
+		want        []int
+	}{
+		{"color or rank", game.Card{Rank: rank.FIVE, Color: color.RED}, "", 0, []int{1, 2, 4}},
+		{"action card by rank", game.Card{Rank: rank.DRAW_2, Color: color.YELLOW}, "", 0, []int{3, 4}},
+		{"color chosen after a wild", game.Card{Rank: rank.WILD}, color.GREEN, 0, []int{2, 4}},
+		{"only stacking cards on a penalty", game.Card{Rank: rank.DRAW_2, Color: color.YELLOW}, "", 2, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			if tt.topColor != "" {
+				s.SetTopCard(tt.top, tt.topColor)
+			} else {
+				s.SetTopCard(tt.top)
+			}
+			s.PendingDraw = tt.pendingDraw
+			player := s.ActivePlayer
+			player.Deck.Cards = append([]game.Card(nil), hand...)
+
+			moves := s.Legal(player)
+			if !reflect.DeepEqual(moves.Cards, tt.want) {
+				t.Errorf("playable cards = %v, want %v", moves.Cards, tt.want)
+			}
+			if !moves.Draw || moves.Pass {
+				t.Errorf("draw = %v, pass = %v, want draw only", moves.Draw, moves.Pass)
+			}
+			for _, card := range hand {
+				legal := false
+				for _, id := range tt.want {
+					legal = legal || id == card.ID
+				}
+				if _, err := s.Apply(player, PlayCard{CardID: card.ID, NewColor: color.RED}); (err == nil) != legal {
+					t.Errorf("playing card %d: err = %v, but it is listed as legal = %v", card.ID, err, legal)
+				}
+				if legal {
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestLegalOutOfTurn(t *testing.T) {
+	s := newTurnTestState(3, 0, true)
+	other := s.Players[1]
+	other.Deck.Cards = []game.Card{
+		{ID: 1, Rank: rank.FIVE, Color: color.RED},
+		{ID: 2, Rank: rank.FIVE, Color: color.BLUE},
+	}
+
+	moves := s.Legal(other)
+	if len(moves.Cards) != 0 || moves.Draw || moves.Pass {
+		t.Errorf("moves out of turn = %+v, want none but UNO", moves)
+	}
+	if !moves.CallUno {
+		t.Errorf("a player with two cards cannot call UNO")
+	}
+
+	s.Rules.JumpIn = true
+	if moves := s.Legal(other); !reflect.DeepEqual(moves.Cards, []int{1}) {
+		t.Errorf("jump-in cards = %v, want [1]", moves.Cards)
+	}
+}
+
+func TestLegalAfterDrawing(t *testing.T) {
+	s := newTurnTestState(2, 0, true)
+	player := s.ActivePlayer
+	player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.THREE, Color: color.RED}}
+	s.Phase = PhaseDrawn
+	s.drawnCard = player.Deck.Cards[0]
+
+	moves := s.Legal(player)
+	if !reflect.DeepEqual(moves.Cards, []int{1}) || moves.Draw || !moves.Pass {
+		t.Errorf("moves after drawing = %+v, want the drawn card or pass", moves)
+	}
+	s.Rules.ForcedPlay = true
+	if s.Legal(player).Pass {
+		t.Errorf("pass is allowed under forced play")
+	}
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+// endRound is called when winner has emptied their hand. A single game ends
+// right away; in a match the winner scores the cards left in the other hands
+// and a new round is dealt until somebody reaches the score target.
+func (s *State) endRound(winner *game.Player) []Event {
+	if !s.Rules.Match {
+		s.Phase = PhaseGameOver
+		return []Event{PlayerWon{Player: winner}}
+	}
+
+	points := 0
+	for _, p := range s.Players {
+		for _, card := range p.Deck.Cards {
+			points += card.Points()
+		}
+	}
+	s.Scores[winner] += points
+	events := []Event{RoundWon{Player: winner, Points: points, Round: s.Round}}
+
+	if s.Scores[winner] >= s.Rules.ScoreTarget {
+		s.Phase = PhaseGameOver
+		return append(events, PlayerWon{Player: winner})
+	}
+	return append(events, s.newRound()...)
+}
+
+// newRound deals a fresh deck to every player. The first turn moves one seat
+// along each round.
+func (s *State) newRound() []Event {
+	s.Round++
+	s.newDecks()
+	s.GameDirection = false
+	s.PendingDraw = 0
+	s.UnoCalled = make(map[*game.Player]bool)
+	s.unoOffender = nil
+	s.draw4 = nil
+	s.jumpedIn = false
+	s.openingWild = false
+
+	for _, p := range s.Players {
+		p.Deck.Cards = make([]game.Card, 0)
+		p.Deck.Counter = 0
+		p.Drawn = false
+		p.AddCards(s.GameDeck.Cut(s.Rules.HandSize))
+	}
+
+	events := []Event{RoundStarted{Round: s.Round}}
+	return append(events, s.openRound((s.Round-1)%len(s.Players))...)
+}
+
+// openRound gives the first turn to the player at seat. Under the
+// OpeningEffects rule the card turned up to start the discard pile acts as if
+// it had been played right before that player's turn.
+func (s *State) openRound(seat int) []Event {
+	var events []Event
+	s.Phase = PhasePlay
+	s.SetActivePlayer(seat)
+
+	if s.Rules.OpeningEffects {
+		first := s.ActivePlayer
+		switch s.TopCard.Rank {
+		case rank.WILD:
+			s.Phase = PhaseChooseColor
+			s.openingWild = true
+		case rank.SKIP:
+			events = append(events, TurnSkipped{Player: first})
+			s.SetActivePlayer(s.seatAfter(seat, 1))
+		case rank.REVERSE:
+			// The player who would otherwise have played last starts instead.
+			events = append(events, s.reverseGameDirection())
+			s.SetActivePlayer(s.seatAfter(seat, 1))
+		case rank.DRAW_2:
+			if s.Rules.Stacking {
+				s.PendingDraw = 2
+				if !s.hasStackableCard(first) {
+					return append(events, s.takePendingDraw(first)...)
+				}
+			} else {
+				events = append(events, s.PerformDrawAction(first, 2)...)
+				events = append(events, TurnSkipped{Player: first})
+				s.SetActivePlayer(s.seatAfter(seat, 1))
+			}
+		}
+	}
+	return append(events, TurnChanged{Player: s.ActivePlayer})
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestEndRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    int // winner's score before the round
+		wantOver bool
+	}{
+		{"next round is dealt", 0, false},
+		{"score target reached", 450, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(2, 0, true)
+			s.Rules.Match = true
+			s.Rules.ScoreTarget = 500
+			winner, loser := s.Players[0], s.Players[1]
+			s.Scores[winner] = tt.score
+			winner.Deck.Cards = []game.Card{{ID: 1, Rank: rank.THREE, Color: color.RED}}
+			loser.Deck.Cards = []game.Card{
+				{ID: 2, Rank: rank.WILD},
+				{ID: 3, Rank: rank.SKIP, Color: color.BLUE},
+				{ID: 4, Rank: rank.SEVEN, Color: color.GREEN},
+			}
+
+			events, err := s.Apply(winner, PlayCard{CardID: 1})
+			if err != nil {
+				t.Fatalf("PlayCard: %v", err)
+			}
+			if e, ok := events[1].(RoundWon); !ok || e.Player != winner || e.Points != 77 || e.Round != 1 {
+				t.Errorf("second event is %#v, want %s winning round 1 with 77 points", events[1], winner.Name)
+			}
+			if got := s.Scores[winner]; got != tt.score+77 {
+				t.Errorf("winner's score = %d, want %d", got, tt.score+77)
+			}
+
+			if tt.wantOver {
+				if s.Phase != PhaseGameOver {
+					t.Errorf("phase = %v, want %v", s.Phase, PhaseGameOver)
+				}
+				if e, ok := events[len(events)-1].(PlayerWon); !ok || e.Player != winner {
+					t.Errorf("last event is %#v, want %s winning the match", events[len(events)-1], winner.Name)
+				}
+				return
+			}
+			if s.Round != 2 || s.Phase == PhaseGameOver {
+				t.Fatalf("round = %d, phase = %v, want round 2 under way", s.Round, s.Phase)
+			}
+			if e, ok := events[2].(RoundStarted); !ok || e.Round != 2 {
+				t.Errorf("third event is %#v, want round 2 starting", events[2])
+			}
+			for _, p := range s.Players {
+				if got := p.Deck.NumberOfCards(); got != s.Rules.HandSize {
+					t.Errorf("%s holds %d cards in the new round, want %d", p.Name, got, s.Rules.HandSize)
+				}
+			}
+			if s.ActivePlayer != loser {
+				t.Errorf("round 2 starts with %s, want %s one seat along", s.ActivePlayer.Name, loser.Name)
+			}
+		})
+	}
+}
+
+func TestOpeningCard(t *testing.T) {
+	stackable := game.Card{ID: 1, Rank: rank.DRAW_2, Color: color.BLUE}
+	plain := game.Card{ID: 2, Rank: rank.NINE, Color: color.YELLOW}
+	tests := []struct {
+		name          string
+		top           game.Card
+		stacking      bool
+		hand          []game.Card // of the first player
+		wantSeat      int
+		wantPhase     Phase
+		wantDirection bool
+		wantPending   int
+		wantDrawn     int // cards drawn by the first player
+	}{
+		{"number", game.Card{Rank: rank.FIVE, Color: color.RED}, false, []game.Card{plain}, 0, PhasePlay, false, 0, 0},
+		{"wild", game.Card{Rank: rank.WILD}, false, []game.Card{plain}, 0, PhaseChooseColor, false, 0, 0},
+		{"skip", game.Card{Rank: rank.SKIP, Color: color.RED}, false, []game.Card{plain}, 2, PhasePlay, false, 0, 0},
+		{"reverse", game.Card{Rank: rank.REVERSE, Color: color.RED}, false, []game.Card{plain}, 1, PhasePlay, true, 0, 0},
+		{"draw_2", game.Card{Rank: rank.DRAW_2, Color: color.RED}, false, []game.Card{stackable}, 2, PhasePlay, false, 0, 2},
+		{"draw_2 stacked", game.Card{Rank: rank.DRAW_2, Color: color.RED}, true, []game.Card{stackable}, 0, PhasePlay, false, 2, 0},
+		{"draw_2 nothing to stack", game.Card{Rank: rank.DRAW_2, Color: color.RED}, true, []game.Card{plain}, 2, PhasePlay, false, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewState(game.Rules{OpeningEffects: true, Stacking: tt.stacking}, 1)
+			for i := 0; i < 3; i++ {
+				s.AddPlayer(game.NewPlayer(fmt.Sprintf("player%d", i)))
+			}
+			first := s.Players[0]
+			first.Deck.Cards = append([]game.Card{}, tt.hand...)
+			s.SetTopCard(tt.top)
+
+			s.Start()
+			if s.CurrentTurn != tt.wantSeat {
+				t.Errorf("CurrentTurn = %d, want %d", s.CurrentTurn, tt.wantSeat)
+			}
+			if s.Phase != tt.wantPhase {
+				t.Errorf("phase = %v, want %v", s.Phase, tt.wantPhase)
+			}
+			if s.GameDirection != tt.wantDirection {
+				t.Errorf("GameDirection = %v, want %v", s.GameDirection, tt.wantDirection)
+			}
+			if s.PendingDraw != tt.wantPending {
+				t.Errorf("PendingDraw = %d, want %d", s.PendingDraw, tt.wantPending)
+			}
+			if got := first.Deck.NumberOfCards(); got != len(tt.hand)+tt.wantDrawn {
+				t.Errorf("first player holds %d cards, want %d", got, len(tt.hand)+tt.wantDrawn)
+			}
+		})
+	}
+}
+
+func TestOpeningWildColorIsPickedByFirstPlayer(t *testing.T) {
+	s := NewState(game.Rules{OpeningEffects: true}, 1)
+	for i := 0; i < 3; i++ {
+		s.AddPlayer(game.NewPlayer(fmt.Sprintf("player%d", i)))
+	}
+	first := s.Players[0]
+	s.SetTopCard(game.Card{Rank: rank.WILD})
+	s.Start()
+
+	if _, err := s.Apply(first, ChooseColor{Color: color.GREEN}); err != nil {
+		t.Fatalf("ChooseColor: %v", err)
+	}
+	if s.TopColor != color.GREEN {
+		t.Errorf("TopColor = %s, want %s", s.TopColor, color.GREEN)
+	}
+	if s.ActivePlayer != first || s.Phase != PhasePlay {
+		t.Errorf("%s is active in phase %v, want %s to still play", s.ActivePlayer.Name, s.Phase, first.Name)
+	}
+}
+
+// Several of these seeds turn up a draw_4 first, which has to go back into
+// the deck.
+func TestOpeningCardIsNeverDraw4(t *testing.T) {
+	for seed := int64(1); seed <= 200; seed++ {
+		s := NewState(game.Rules{OpeningEffects: true}, seed)
+		if s.TopCard.Rank == rank.DRAW_4 {
+			t.Fatalf("seed %d opens on a draw_4", seed)
+		}
+		if got := s.GameDeck.NumberOfCards() + s.DisposedGameDeck.NumberOfCards(); got != game.DeckSize {
+			t.Fatalf("seed %d: %d cards in the piles, want %d", seed, got, game.DeckSize)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"uno/models/constants/color"
+	"uno/models/game"
+)
+
+// Move is an action a player asks the engine to perform.
+type Move interface {
+	isMove()
+}
+
+// PlayCard plays the card with CardID from the player's hand. NewColor is only
+// used for wild cards; when it is empty the engine waits for a ChooseColor move.
+// CallUno calls UNO together with the second to last card.
+type PlayCard struct {
+	CardID   int
+	NewColor color.Color
+	CallUno  bool
+}
+
+// DrawCard draws from the draw pile according to the room's draw mode.
+type DrawCard struct{}
+
+// Pass keeps the playable card that was just drawn and ends the turn.
+type Pass struct{}
+
+// ChooseColor picks the color for a wild card played without one.
+type ChooseColor struct {
+	Color color.Color
+}
+
+// ChallengeDraw4 claims the previous wild draw_4 was played while the
+// offender still held a card of the color in play.
+type ChallengeDraw4 struct{}
+
+// AcceptDraw4 takes the four cards of a wild draw_4 without challenging it.
+type AcceptDraw4 struct{}
+
+// CallUno announces that the player is about to be left with a single card.
+type CallUno struct{}
+
+// CatchUno catches a player who went down to one card without calling UNO.
+type CatchUno struct{}
+
+// SwapHands picks the opponent to swap hands with after playing a 7 under
+// the Seven-O rule.
+type SwapHands struct {
+	Target *game.Player
+}
+
+func (PlayCard) isMove()       {}
+func (DrawCard) isMove()       {}
+func (Pass) isMove()           {}
+func (ChooseColor) isMove()    {}
+func (ChallengeDraw4) isMove() {}
+func (AcceptDraw4) isMove()    {}
+func (CallUno) isMove()        {}
+func (CatchUno) isMove()       {}
+func (SwapHands) isMove()      {}
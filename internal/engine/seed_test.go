@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"uno/models/game"
+)
+
+// TestSameSeedReplays plays two games from the same seed with the server's
+// automatic moves and checks they stay identical card for card, through
+// reshuffles of the discard pile.
+func TestSameSeedReplays(t *testing.T) {
+	newGame := func() *State {
+		s := NewState(game.Rules{}, 42)
+		for i := 0; i < 3; i++ {
+			s.AddPlayer(game.NewPlayer(fmt.Sprintf("player%d", i)))
+		}
+		s.Start()
+		return s
+	}
+	a, b := newGame(), newGame()
+
+	reshuffles := 0
+	for i := 0; i < 500 && a.Phase != PhaseGameOver; i++ {
+		if va, vb := viewTable(a), viewTable(b); !reflect.DeepEqual(va, vb) {
+			t.Fatalf("move %d: the games differ:\n%+v\n%+v", i, va, vb)
+		}
+		events, err := a.Apply(a.ActivePlayer, a.AutoMove(a.ActivePlayer))
+		if err != nil {
+			t.Fatalf("move %d: %v", i, err)
+		}
+		if _, err := b.Apply(b.ActivePlayer, b.AutoMove(b.ActivePlayer)); err != nil {
+			t.Fatalf("move %d: %v", i, err)
+		}
+		for _, event := range events {
+			if _, ok := event.(DeckReshuffled); ok {
+				reshuffles++
+			}
+		}
+	}
+	if reshuffles == 0 {
+		t.Errorf("the discard pile was never reshuffled; play longer")
+	}
+}
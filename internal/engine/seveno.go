@@ -0,0 +1,43 @@
+package engine
+
+import "uno/models/game"
+
+// swapHands settles the 7 played by p under the Seven-O rule.
+func (s *State) swapHands(p *game.Player, m SwapHands) ([]Event, error) {
+	if m.Target == nil || m.Target == p || s.seatOf(m.Target) < 0 {
+		return nil, ErrBadTarget
+	}
+
+	p.Deck.Cards, m.Target.Deck.Cards = m.Target.Deck.Cards, p.Deck.Cards
+	p.Deck.Counter, m.Target.Deck.Counter = m.Target.Deck.Counter, p.Deck.Counter
+	s.resetUnoCalls()
+	s.Phase = PhasePlay
+
+	events := []Event{HandsSwapped{Player: p, Target: m.Target}}
+	return append(events, s.NextTurn()...), nil
+}
+
+// rotateHands passes every hand to the next player in the direction of play.
+// Only the card slices move; every player keeps their own Deck.
+func (s *State) rotateHands() Event {
+	n := len(s.Players)
+	cards := make([][]game.Card, n)
+	counters := make([]int, n)
+	for i, p := range s.Players {
+		next := s.seatAfter(i, 1)
+		cards[next] = p.Deck.Cards
+		counters[next] = p.Deck.Counter
+	}
+	for i, p := range s.Players {
+		p.Deck.Cards = cards[i]
+		p.Deck.Counter = counters[i]
+	}
+	s.resetUnoCalls()
+	return HandsRotated{Direction: s.GameDirection}
+}
+
+// resetUnoCalls forgets UNO calls once hands have changed owners.
+func (s *State) resetUnoCalls() {
+	s.UnoCalled = make(map[*game.Player]bool)
+	s.unoOffender = nil
+}
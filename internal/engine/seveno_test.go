@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestSwapHands(t *testing.T) {
+	s := newTurnTestState(3, 0, true)
+	s.Rules.SevenO = true
+	player, next, target := s.Players[0], s.Players[1], s.Players[2]
+	kept := game.Card{ID: 2, Rank: rank.NINE, Color: color.YELLOW}
+	player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.SEVEN, Color: color.RED}, kept}
+	targetHand := []game.Card{{ID: 3, Rank: rank.ONE, Color: color.BLUE}, {ID: 4, Rank: rank.TWO, Color: color.BLUE}}
+	target.Deck.Cards = append([]game.Card{}, targetHand...)
+
+	if _, err := s.Apply(player, PlayCard{CardID: 1}); err != nil {
+		t.Fatalf("PlayCard: %v", err)
+	}
+	if s.Phase != PhaseSwapHands || s.ActivePlayer != player {
+		t.Fatalf("phase = %v, want %v for the player of the 7", s.Phase, PhaseSwapHands)
+	}
+	for name, bad := range map[string]*game.Player{"yourself": player, "nobody": nil, "a stranger": game.NewPlayer("stranger")} {
+		if _, err := s.Apply(player, SwapHands{Target: bad}); err != ErrBadTarget {
+			t.Errorf("swapping with %s: err = %v, want %v", name, err, ErrBadTarget)
+		}
+	}
+
+	if _, err := s.Apply(player, SwapHands{Target: target}); err != nil {
+		t.Fatalf("SwapHands: %v", err)
+	}
+	if !reflect.DeepEqual(player.Deck.Cards, targetHand) {
+		t.Errorf("player holds %v, want the target's hand %v", player.Deck.Cards, targetHand)
+	}
+	if !reflect.DeepEqual(target.Deck.Cards, []game.Card{kept}) {
+		t.Errorf("target holds %v, want %v", target.Deck.Cards, []game.Card{kept})
+	}
+	if s.Phase != PhasePlay || s.ActivePlayer != next {
+		t.Errorf("phase = %v with %s active, want %v for %s", s.Phase, s.ActivePlayer.Name, PhasePlay, next.Name)
+	}
+}
+
+func TestSevenWithoutOpponent(t *testing.T) {
+	s := newTurnTestState(1, 0, true)
+	s.Rules.SevenO = true
+	player := s.Players[0]
+	player.Deck.Cards = []game.Card{{ID: 1, Rank: rank.SEVEN, Color: color.RED}, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}}
+
+	if _, err := s.Apply(player, PlayCard{CardID: 1}); err != nil {
+		t.Fatalf("PlayCard: %v", err)
+	}
+	if s.Phase != PhasePlay {
+		t.Errorf("phase = %v, want %v", s.Phase, PhasePlay)
+	}
+}
+
+func TestRotateHands(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction bool
+		from      []int // seat whose hand every seat ends up with
+	}{
+		{"clockwise", true, []int{2, 0, 1}},
+		{"counterclockwise", false, []int{1, 2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(3, 0, tt.direction)
+			s.Rules.SevenO = true
+			s.Players[0].Deck.Cards = []game.Card{{ID: 1, Rank: rank.ZERO, Color: color.RED}, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}}
+			s.Players[1].Deck.Cards = []game.Card{{ID: 3, Rank: rank.ONE, Color: color.BLUE}, {ID: 4, Rank: rank.TWO, Color: color.BLUE}}
+			s.Players[2].Deck.Cards = []game.Card{{ID: 5, Rank: rank.THREE, Color: color.GREEN}}
+
+			var hands [][]game.Card
+			for _, p := range s.Players {
+				hands = append(hands, append([]game.Card{}, p.Deck.Cards...))
+			}
+			hands[0] = hands[0][1:] // the 0 is played before the hands move
+
+			events, err := s.Apply(s.Players[0], PlayCard{CardID: 1})
+			if err != nil {
+				t.Fatalf("PlayCard: %v", err)
+			}
+			if e, ok := events[1].(HandsRotated); !ok || e.Direction != tt.direction {
+				t.Errorf("second event is %#v, want hands rotated in direction %v", events[1], tt.direction)
+			}
+			for seat, p := range s.Players {
+				if want := hands[tt.from[seat]]; !reflect.DeepEqual(p.Deck.Cards, want) {
+					t.Errorf("seat %d holds %v, want %v from seat %d", seat, p.Deck.Cards, want, tt.from[seat])
+				}
+			}
+		})
+	}
+}
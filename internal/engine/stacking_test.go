@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestStacking(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     game.Card
+		answer    game.Card
+		wantTotal int
+	}{
+		{"draw_2 on draw_2", game.Card{ID: 1, Rank: rank.DRAW_2, Color: color.RED}, game.Card{ID: 3, Rank: rank.DRAW_2, Color: color.GREEN}, 4},
+		{"draw_4 on draw_4", game.Card{ID: 1, Rank: rank.DRAW_4}, game.Card{ID: 3, Rank: rank.DRAW_4}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(3, 0, true)
+			s.Rules.Stacking = true
+			first, second, third := s.Players[0], s.Players[1], s.Players[2]
+			first.Deck.Cards = []game.Card{tt.first, {ID: 2, Rank: rank.NINE, Color: color.YELLOW}}
+			second.Deck.Cards = []game.Card{tt.answer, {ID: 4, Rank: rank.THREE, Color: color.RED}}
+			third.Deck.Cards = []game.Card{{ID: 5, Rank: rank.ONE, Color: color.BLUE}, {ID: 6, Rank: rank.NINE, Color: color.BLUE}}
+
+			if _, err := s.Apply(first, PlayCard{CardID: tt.first.ID, NewColor: color.RED}); err != nil {
+				t.Fatalf("first draw card: %v", err)
+			}
+			if s.ActivePlayer != second || s.PendingDraw != tt.wantTotal/2 {
+				t.Fatalf("after the first draw card: %s owes %d, want %s to owe %d", s.ActivePlayer.Name, s.PendingDraw, second.Name, tt.wantTotal/2)
+			}
+			if _, err := s.Apply(second, PlayCard{CardID: 4}); err != ErrIllegalCard {
+				t.Errorf("playing a red 3 on a pending penalty: err = %v, want %v", err, ErrIllegalCard)
+			}
+
+			events, err := s.Apply(second, PlayCard{CardID: tt.answer.ID, NewColor: color.RED})
+			if err != nil {
+				t.Fatalf("stacked draw card: %v", err)
+			}
+			if got := third.Deck.NumberOfCards(); got != 2+tt.wantTotal {
+				t.Errorf("the player who cannot stack holds %d cards, want %d", got, 2+tt.wantTotal)
+			}
+			if s.PendingDraw != 0 {
+				t.Errorf("PendingDraw = %d after the penalty was taken, want 0", s.PendingDraw)
+			}
+			if s.ActivePlayer != first {
+				t.Errorf("turn went to %s, want %s after %s was skipped", s.ActivePlayer.Name, first.Name, third.Name)
+			}
+			skipped := false
+			for _, event := range events {
+				if e, ok := event.(TurnSkipped); ok && e.Player == third {
+					skipped = true
+				}
+			}
+			if !skipped {
+				t.Errorf("no TurnSkipped for %s", third.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Package engine implements the UNO rules independently of any transport.
+//
+// The engine owns the table state and turns player moves into a list of
+// events. It never talks to the network; callers decide how the events are
+// presented to the players.
+package engine
+
+import (
+	"math/rand"
+	"uno/models/constants/color"
+	"uno/models/game"
+)
+
+// Phase describes what the engine is waiting for from the active player.
+type Phase int
+
+const (
+	// PhasePlay waits for the active player to play or draw a card.
+	PhasePlay Phase = iota
+	// PhaseDrawn waits for the active player to play the playable card they
+	// just drew, or to pass.
+	PhaseDrawn
+	// PhaseChooseColor waits for the active player to pick a color for the wild card they played.
+	PhaseChooseColor
+	// PhaseChallengeDraw4 waits for the victim of a wild draw_4 to accept or challenge it.
+	PhaseChallengeDraw4
+	// PhaseSwapHands waits for the player who played a 7 to pick whom to swap hands with.
+	PhaseSwapHands
+	// PhaseGameOver means the game has been won; no more moves are accepted.
+	PhaseGameOver
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhasePlay:
+		return "play"
+	case PhaseDrawn:
+		return "drawn"
+	case PhaseChooseColor:
+		return "choose_color"
+	case PhaseChallengeDraw4:
+		return "challenge_draw_4"
+	case PhaseSwapHands:
+		return "swap_hands"
+	case PhaseGameOver:
+		return "game_over"
+	}
+	return "unknown"
+}
+
+type State struct {
+	Players          []*game.Player
+	GameDeck         *game.GameDeck
+	DisposedGameDeck *game.GameDeck
+	CurrentTurn      int
+	GameDirection    bool
+	ActivePlayer     *game.Player //pointer to active player
+	TopCard          game.Card
+	TopColor         color.Color
+	Phase            Phase
+	Rules            game.Rules
+	PendingDraw      int // cards owed by the active player under the stacking rule
+	UnoCalled        map[*game.Player]bool
+	Round            int
+	Scores           map[*game.Player]int
+	Seed             int64 // seed of every shuffle, enough to replay the game card for card
+	Version          int   // bumped on every change, so clients can tell stale state apart
+
+	rand        *rand.Rand
+	unoOffender *game.Player // player who can still be caught for not calling UNO
+	draw4       *draw4Play   // last wild draw_4, kept until its victim accepts or challenges it
+	drawnCard   game.Card    // playable card drawn by the active player during PhaseDrawn
+	jumpedIn    bool         // the top card was played out of turn and cannot be jumped on again
+	openingWild bool         // the first player picks the color of the opening wild card
+}
+
+// draw4Play remembers what the table looked like when a wild draw_4 was played,
+// so a challenge can be judged against the offender's hand before the play.
+type draw4Play struct {
+	offender *game.Player
+	hand     []game.Card
+	color    color.Color
+}
+
+// NewState prepares a table for the given rules. All the randomness of the
+// game comes from seed.
+func NewState(rules game.Rules, seed int64) *State {
+	s := &State{
+		Players:       make([]*game.Player, 0),
+		GameDirection: false,
+		Rules:         rules.WithDefaults(),
+		UnoCalled:     make(map[*game.Player]bool),
+		Round:         1,
+		Scores:        make(map[*game.Player]int),
+		Seed:          seed,
+		rand:          rand.New(rand.NewSource(seed)),
+	}
+	s.newDecks()
+	return s
+}
+
+// newDecks replaces both piles with a freshly shuffled deck and turns up the first card.
+func (s *State) newDecks() {
+	s.GameDeck = game.NewGameDeck(s.rand) //Initialised Game Deck
+	s.DisposedGameDeck = &game.GameDeck{
+		Deck: &game.Deck{
+			Cards: make([]game.Card, 0), // Initialize the Cards slice
+		},
+	}
+	if s.Rules.OpeningEffects {
+		s.SetTopCard(*s.GameDeck.TurnUpStartCard())
+	} else {
+		s.SetTopCard(*s.GameDeck.GetStartCard())
+	}
+	s.DisposedGameDeck.AddCard(s.TopCard)
+}
+
+// AddPlayer deals a starting hand to the player and seats them at the table.
+func (s *State) AddPlayer(player *game.Player) {
+	player.AddCards(s.GameDeck.Cut(s.Rules.HandSize))
+	s.Players = append(s.Players, player)
+	s.Version++
+}
+
+// Start hands the first turn to the first seated player and applies the
+// effect of the opening card.
+func (s *State) Start() []Event {
+	s.Version++
+	return s.openRound(0)
+}
+
+func (s *State) SetActivePlayer(index int) {
+	s.CurrentTurn = index
+	s.ActivePlayer = s.Players[index]
+}
+
+func (s *State) SetTopCard(card game.Card, color ...color.Color) {
+	if card.Type() == "action-card-no-color" && len(color) > 0 {
+		s.TopCard = card
+		s.TopColor = color[0]
+	} else {
+		s.TopCard = card
+		s.TopColor = card.Color
+	}
+}
+
+func (s *State) IsValidMove(playedCard game.Card, player *game.Player) bool {
+	if player != s.ActivePlayer {
+		return false
+	}
+	if s.TopCard.Type() == "action-card-no-color" {
+		return playedCard.Color == s.TopColor
+	}
+	// If the played card matches the color or rank of the top card, it's a valid move
+	return playedCard.IsSameColor(s.TopCard) || playedCard.IsSameRank(s.TopCard)
+}
+
+// ShuffleDiscardPileToDeck turns every discarded card except the top one into
+// a new draw pile. Wild cards lose the color they were played as. It reports
+// false when there was nothing to shuffle.
+func (s *State) ShuffleDiscardPileToDeck() bool {
+	discarded := s.DisposedGameDeck.Deck.Cards
+	if len(discarded) <= 1 {
+		return false
+	}
+	top := discarded[len(discarded)-1]
+
+	cards := make([]game.Card, 0, len(discarded)-1)
+	for _, card := range discarded[:len(discarded)-1] {
+		if card.Type() == "action-card-no-color" {
+			card.Color = ""
+		}
+		cards = append(cards, card)
+	}
+	s.GameDeck.Deck.Cards = append(s.GameDeck.Deck.Cards, cards...)
+	s.GameDeck.Deck.Counter = len(s.GameDeck.Deck.Cards)
+	s.GameDeck.Shuffle()
+
+	s.DisposedGameDeck.Deck.Cards = []game.Card{top}
+	s.DisposedGameDeck.Deck.Counter = 1
+	return true
+}
+
+// canPlay reports whether the active player may put card on the discard pile
+// right now. While a stacked penalty is pending only a stacking card goes.
+func (s *State) canPlay(card game.Card) bool {
+	if s.PendingDraw > 0 {
+		return s.canStack(card)
+	}
+	if card.Type() == "action-card-no-color" {
+		return true
+	}
+	return s.IsValidMove(card, s.ActivePlayer)
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+// NextTurn ends the active player's turn and hands it to the next player,
+// unless the active player has just won.
+func (s *State) NextTurn() []Event {
+	return s.advanceTurn(false)
+}
+
+// advanceTurn is the only place the turn moves from one seat to another. It
+// ends the active player's turn and hands it to the next player in the
+// direction of play, or to the one after when skip is set. With two players a
+// skip gives the turn straight back to the active player.
+func (s *State) advanceTurn(skip bool) []Event {
+	var events []Event
+	s.ActivePlayer.Drawn = false
+
+	//check for Game winner
+	if s.ActivePlayer.Deck.NumberOfCards() == 0 {
+		return append(events, s.endRound(s.ActivePlayer)...)
+	}
+	//Check for UNO
+	if s.ActivePlayer.Deck.NumberOfCards() == 1 {
+		called := s.UnoCalled[s.ActivePlayer]
+		if !called {
+			s.unoOffender = s.ActivePlayer
+		}
+		events = append(events, LastCard{Player: s.ActivePlayer, Called: called})
+	}
+
+	steps := 1
+	if skip {
+		events = append(events, TurnSkipped{Player: s.nextPlayer()})
+		steps = 2
+	}
+	s.SetActivePlayer(s.seatAfter(s.CurrentTurn, steps))
+	events = append(events, TurnChanged{Player: s.ActivePlayer})
+
+	// The first player who cannot answer a stacked draw takes the whole penalty.
+	if s.PendingDraw > 0 && !s.hasStackableCard(s.ActivePlayer) {
+		events = append(events, s.takePendingDraw(s.ActivePlayer)...)
+	}
+	return events
+}
+
+// dealwithActionCards applies the effect of a colored action card and tells
+// whether the next player loses their turn.
+func (s *State) dealwithActionCards(card game.Card) ([]Event, bool) {
+	switch card.Rank {
+	case rank.SKIP:
+		return nil, true
+	case rank.DRAW_2:
+		if s.Rules.Stacking {
+			return []Event{s.stackDraw(2)}, false
+		}
+		return s.PerformDrawAction(s.nextPlayer(), 2), true
+	case rank.REVERSE:
+		// With two players a reverse works like a skip.
+		return []Event{s.reverseGameDirection()}, len(s.Players) == 2
+	}
+	return nil, false
+}
+
+// reverseGameDirection reverses the game direction
+func (s *State) reverseGameDirection() Event {
+	s.GameDirection = !s.GameDirection
+	return DirectionReversed{Direction: s.GameDirection}
+}
+
+// nextPlayer returns the player seated after the active player in the direction of play.
+func (s *State) nextPlayer() *game.Player {
+	return s.Players[s.seatAfter(s.CurrentTurn, 1)]
+}
+
+// seatAfter returns the seat steps places after seat in the direction of play.
+func (s *State) seatAfter(seat, steps int) int {
+	n := len(s.Players)
+	next := (seat + steps*convertDirectionToInteger(s.GameDirection)) % n
+	if next < 0 {
+		next += n
+	}
+	return next
+}
+
+// seatOf returns the seat of p, or -1 when p is not at the table.
+func (s *State) seatOf(p *game.Player) int {
+	for i, player := range s.Players {
+		if player == p {
+			return i
+		}
+	}
+	return -1
+}
+
+func convertDirectionToInteger(direction bool) int {
+	if direction {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+// newTurnTestState seats the given number of players and makes seat the
+// active one, with a red 5 on the discard pile.
+func newTurnTestState(players, seat int, direction bool) *State {
+	s := NewState(game.Rules{}, 1)
+	for i := 0; i < players; i++ {
+		s.AddPlayer(game.NewPlayer(fmt.Sprintf("player%d", i)))
+	}
+	s.Start()
+	s.GameDirection = direction
+	s.SetActivePlayer(seat)
+	s.SetTopCard(game.Card{Rank: rank.FIVE, Color: color.RED})
+	return s
+}
+
+func TestAdvanceTurn(t *testing.T) {
+	tests := []struct {
+		name          string
+		players       int
+		seat          int
+		direction     bool
+		card          game.Card
+		wantSeat      int
+		wantDirection bool
+		victimSeat    int // seat that has to draw, or -1
+		wantDrawn     int
+	}{
+		{"number 4 players", 4, 0, false, game.Card{Rank: rank.THREE, Color: color.RED}, 3, false, -1, 0},
+		{"number wraps forward", 4, 3, true, game.Card{Rank: rank.THREE, Color: color.RED}, 0, true, -1, 0},
+		{"skip 4 players", 4, 0, false, game.Card{Rank: rank.SKIP, Color: color.RED}, 2, false, -1, 0},
+		{"skip wraps forward", 4, 3, true, game.Card{Rank: rank.SKIP, Color: color.RED}, 1, true, -1, 0},
+		{"reverse 4 players", 4, 0, false, game.Card{Rank: rank.REVERSE, Color: color.RED}, 1, true, -1, 0},
+		{"draw_2 4 players", 4, 0, false, game.Card{Rank: rank.DRAW_2, Color: color.RED}, 2, false, 3, 2},
+		{"draw_4 4 players", 4, 0, false, game.Card{Rank: rank.DRAW_4}, 2, false, 3, 4},
+
+		{"number 3 players", 3, 0, false, game.Card{Rank: rank.THREE, Color: color.RED}, 2, false, -1, 0},
+		{"skip 3 players", 3, 0, false, game.Card{Rank: rank.SKIP, Color: color.RED}, 1, false, -1, 0},
+		{"reverse 3 players", 3, 0, false, game.Card{Rank: rank.REVERSE, Color: color.RED}, 1, true, -1, 0},
+		{"draw_2 3 players", 3, 0, false, game.Card{Rank: rank.DRAW_2, Color: color.RED}, 1, false, 2, 2},
+		{"draw_4 3 players", 3, 0, false, game.Card{Rank: rank.DRAW_4}, 1, false, 2, 4},
+
+		{"number 2 players", 2, 0, false, game.Card{Rank: rank.THREE, Color: color.RED}, 1, false, -1, 0},
+		{"skip 2 players", 2, 0, false, game.Card{Rank: rank.SKIP, Color: color.RED}, 0, false, -1, 0},
+		{"reverse 2 players acts as skip", 2, 0, false, game.Card{Rank: rank.REVERSE, Color: color.RED}, 0, true, -1, 0},
+		{"reverse 2 players second seat", 2, 1, true, game.Card{Rank: rank.REVERSE, Color: color.RED}, 1, false, -1, 0},
+		{"draw_2 2 players", 2, 0, false, game.Card{Rank: rank.DRAW_2, Color: color.RED}, 0, false, 1, 2},
+		{"draw_4 2 players", 2, 0, false, game.Card{Rank: rank.DRAW_4}, 0, false, 1, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(tt.players, tt.seat, tt.direction)
+			player := s.ActivePlayer
+			card := tt.card
+			card.ID = 1
+			player.Deck.Cards = []game.Card{card, {ID: 2, Rank: rank.ONE, Color: color.GREEN}}
+
+			handSizes := make([]int, tt.players)
+			for i, p := range s.Players {
+				handSizes[i] = p.Deck.NumberOfCards()
+			}
+
+			if _, err := s.Apply(player, PlayCard{CardID: card.ID, NewColor: color.BLUE}); err != nil {
+				t.Fatalf("PlayCard: %v", err)
+			}
+			if tt.card.Rank == rank.DRAW_4 {
+				if s.Phase != PhaseChallengeDraw4 {
+					t.Fatalf("phase = %v, want %v", s.Phase, PhaseChallengeDraw4)
+				}
+				if _, err := s.Apply(s.ActivePlayer, AcceptDraw4{}); err != nil {
+					t.Fatalf("AcceptDraw4: %v", err)
+				}
+			}
+
+			if s.CurrentTurn != tt.wantSeat {
+				t.Errorf("CurrentTurn = %d, want %d", s.CurrentTurn, tt.wantSeat)
+			}
+			if s.ActivePlayer != s.Players[s.CurrentTurn] {
+				t.Errorf("ActivePlayer is %s, but seat %d is %s", s.ActivePlayer.Name, s.CurrentTurn, s.Players[s.CurrentTurn].Name)
+			}
+			if s.GameDirection != tt.wantDirection {
+				t.Errorf("GameDirection = %v, want %v", s.GameDirection, tt.wantDirection)
+			}
+			for i, p := range s.Players {
+				if p == player {
+					continue
+				}
+				want := handSizes[i]
+				if i == tt.victimSeat {
+					want += tt.wantDrawn
+				}
+				if got := p.Deck.NumberOfCards(); got != want {
+					t.Errorf("seat %d holds %d cards, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSeatAfter(t *testing.T) {
+	tests := []struct {
+		players   int
+		seat      int
+		steps     int
+		direction bool
+		want      int
+	}{
+		{4, 0, 1, true, 1},
+		{4, 0, 1, false, 3},
+		{4, 3, 2, true, 1},
+		{4, 1, 2, false, 3},
+		{2, 0, 2, true, 0},
+		{2, 1, 2, false, 1},
+		{3, 2, 1, true, 0},
+	}
+
+	for _, tt := range tests {
+		s := newTurnTestState(tt.players, 0, tt.direction)
+		if got := s.seatAfter(tt.seat, tt.steps); got != tt.want {
+			t.Errorf("seatAfter(%d, %d) with %d players, direction %v = %d, want %d", tt.seat, tt.steps, tt.players, tt.direction, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+	"uno/models/game"
+)
+
+func TestCatchUno(t *testing.T) {
+	tests := []struct {
+		name        string
+		callUno     bool // the offender calls UNO with their second to last card
+		nextActs    bool // the next player makes their move before the catch
+		catcherSeat int
+		want        error
+	}{
+		{"caught by another player", false, false, 2, nil},
+		{"caught by the next player", false, false, 1, nil},
+		{"too late once the next player acted", false, true, 2, ErrNothingToCatch},
+		{"UNO called on the play", true, false, 2, ErrNothingToCatch},
+		{"cannot catch yourself", false, false, 0, ErrNothingToCatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTurnTestState(3, 0, true)
+			offender, next := s.Players[0], s.Players[1]
+			offender.Deck.Cards = []game.Card{{ID: 1, Rank: rank.THREE, Color: color.RED}, {ID: 2, Rank: rank.NINE, Color: color.GREEN}}
+			next.Deck.Cards = []game.Card{{ID: 3, Rank: rank.THREE, Color: color.BLUE}, {ID: 4, Rank: rank.EIGHT, Color: color.YELLOW}, {ID: 5, Rank: rank.ONE, Color: color.YELLOW}}
+
+			if _, err := s.Apply(offender, PlayCard{CardID: 1, CallUno: tt.callUno}); err != nil {
+				t.Fatalf("PlayCard: %v", err)
+			}
+			if tt.nextActs {
+				if _, err := s.Apply(next, PlayCard{CardID: 3}); err != nil {
+					t.Fatalf("next player's PlayCard: %v", err)
+				}
+			}
+
+			catcher := s.Players[tt.catcherSeat]
+			events, err := s.Apply(catcher, CatchUno{})
+			if err != tt.want {
+				t.Fatalf("CatchUno: err = %v, want %v", err, tt.want)
+			}
+			want := 1
+			if err == nil {
+				want = 3
+				if e, ok := events[0].(UnoCaught); !ok || e.Catcher != catcher || e.Offender != offender {
+					t.Errorf("first event is %#v, want %s catching %s", events[0], catcher.Name, offender.Name)
+				}
+				if _, err := s.Apply(s.Players[2], CatchUno{}); err != ErrNothingToCatch {
+					t.Errorf("second catch: err = %v, want %v", err, ErrNothingToCatch)
+				}
+			}
+			if got := offender.Deck.NumberOfCards(); got != want {
+				t.Errorf("offender holds %d cards, want %d", got, want)
+			}
+		})
+	}
+}
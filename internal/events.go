@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"fmt"
+	"log"
+	"uno/internal/engine"
+	"uno/models/dtos"
+)
+
+// publish turns the events produced by the rules engine into messages for the players.
+func (g *Game) publish(events []engine.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case engine.CardPlayed:
+			if e.Color != "" {
+				g.Network.BroadcastInfoMessage(fmt.Sprintf("%s played %s and changed the color to %s", e.Player.Name, e.Card.LogCard(), e.Color))
+			} else {
+				g.Network.BroadcastInfoMessage(fmt.Sprintf("%s played %s", e.Player.Name, e.Card.LogCard()))
+			}
+		case engine.JumpedIn:
+			dto := dtos.JumpInDTO{PlayerID: e.Player.ID, Card: e.Card}
+			g.Network.BroadcastMessage(dto.Serialize())
+		case engine.ColorChosen:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("%s changed the color to %s", e.Player.Name, e.Color))
+		case engine.CardsDrawn:
+			for _, card := range e.Cards {
+				g.Network.SendInfoMessage(e.Player, fmt.Sprintf("%s Drew %s", e.Player.Name, card.LogCard()))
+			}
+			g.Network.SendInfoMessage(e.Player, fmt.Sprintf("%s Drew %d cards", e.Player.Name, len(e.Cards)))
+		case engine.DrawnCardPlayable:
+			if e.Forced {
+				g.Network.SendInfoMessage(e.Player, fmt.Sprintf("You can play %s and must play it.", e.Card.LogCard()))
+			} else {
+				g.Network.SendInfoMessage(e.Player, fmt.Sprintf("You can play %s or pass.", e.Card.LogCard()))
+			}
+		case engine.DeckReshuffled:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("The discard pile was shuffled into a new draw pile of %d cards", e.Cards))
+		case engine.DeckExhausted:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("No cards left to draw, %d cards could not be drawn", e.Missing))
+		case engine.TurnSkipped:
+			g.Network.SendInfoMessage(e.Player, "Your turn is SKIPPED")
+		case engine.DrawStacked:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("%s stacked a draw card. Next player draws %d cards", e.Player.Name, e.Total))
+		case engine.Draw4Pending:
+			g.Network.SendInfoMessage(e.Victim, fmt.Sprintf("%s played a wild draw 4. Accept it or challenge it.", e.Offender.Name))
+		case engine.Draw4Challenged:
+			if e.Successful {
+				g.Network.BroadcastInfoMessage(fmt.Sprintf("%s successfully challenged %s's wild draw 4", e.Challenger.Name, e.Offender.Name))
+			} else {
+				g.Network.BroadcastInfoMessage(fmt.Sprintf("%s failed to challenge %s's wild draw 4", e.Challenger.Name, e.Offender.Name))
+			}
+		case engine.HandsSwapped:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("%s swapped hands with %s", e.Player.Name, e.Target.Name))
+		case engine.HandsRotated:
+			g.Network.BroadcastInfoMessage("Every hand was passed to the next player")
+		case engine.DirectionReversed:
+			log.Println("Game direction reversed now")
+		case engine.LastCard:
+			if !e.Called {
+				g.Network.BroadcastInfoMessage(fmt.Sprintf("%s has one card left and did not call UNO", e.Player.Name))
+			}
+		case engine.UnoCalled:
+			for _, p := range g.Players {
+				g.Network.SendInfoMessage(p, fmt.Sprintf("UNO !!!! by %s ", e.Player.Name))
+			}
+		case engine.UnoCaught:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("%s caught %s not calling UNO. %s draws 2 cards", e.Catcher.Name, e.Offender.Name, e.Offender.Name))
+		case engine.TurnChanged:
+			g.Network.SendInfoMessage(e.Player, "It is your turn.")
+		case engine.RoundWon:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("%s won round %d and scored %d points", e.Player.Name, e.Round, e.Points))
+			g.sendScoreboard(e)
+		case engine.RoundStarted:
+			g.Network.BroadcastInfoMessage(fmt.Sprintf("Round %d has started", e.Round))
+		case engine.PlayerWon:
+			g.declareWinner(e.Player)
+		default:
+			log.Printf("Unhandled game event: %T", e)
+		}
+	}
+}
+
+func (g *Game) sendScoreboard(round engine.RoundWon) {
+	scores := make(map[string]int)
+	for _, p := range g.Players {
+		scores[p.ID] = g.Scores[p]
+	}
+	dto := dtos.ScoreboardDTO{
+		Round:       round.Round,
+		RoundWinner: round.Player.ID,
+		Points:      round.Points,
+		Scores:      scores,
+		ScoreTarget: g.Rules.ScoreTarget,
+		MatchOver:   g.Scores[round.Player] >= g.Rules.ScoreTarget,
+	}
+	g.Network.BroadcastMessage(dto.Serialize())
+}
@@ -1,193 +1,254 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"log"
-	"math/rand"
+	"strings"
 	"sync"
 	"time"
+	"uno/internal/bot"
+	"uno/internal/engine"
 	"uno/models/commands"
 	"uno/models/constants/color"
-	"uno/models/constants/rank"
+	"uno/models/constants/errcode"
 	"uno/models/dtos"
 	"uno/models/game"
-
-	"github.com/gorilla/websocket"
 )
 
 type Game struct {
-	Players          []*game.Player
-	GameDeck         *game.GameDeck
-	DisposedGameDeck *game.GameDeck
-	Room             *Room
-	GameStarted      bool
-	CurrentTurn      int
-	GameDirection    bool
-	ActivePlayer     *game.Player //pointer to active player
-	mu               sync.Mutex
-	TopCard          game.Card
-	TopColor         color.Color
-	GameFirstMove    bool
-	Network          Network
-}
-
-func NewGame() *Game {
-	gameDeck := game.NewGameDeck() //Initialised Game Deck
-	disposedGameDeck := &game.GameDeck{
-		Deck: &game.Deck{
-			Cards: make([]game.Card, 0), // Initialize the Cards slice
-		},
-	}
-	var (
-		game = &Game{
-			Players:          make([]*game.Player, 0),
-			GameDeck:         gameDeck,
-			DisposedGameDeck: disposedGameDeck,
-			GameStarted:      false,
-			GameDirection:    false,
-			Network:          *NewNetwork(),
-		}
-	)
-	game.SetTopCard(*gameDeck.GetStartCard())
-	return game
+	*engine.State
+	Room          *Room
+	GameStarted   bool
+	mu            sync.Mutex
+	GameFirstMove bool
+	Network       Network
+	seqs          map[string]int // last command seq handled per player id
+	lastAction    *dtos.PatchEvent
+	away          map[string]chan struct{} // disconnected players, with a channel closed on their return while the grace period runs
+	bots          map[string]bot.Strategy
+	timeouts      map[string]int // turns in a row a player ran out of time
+
+	clock      Clock
+	clockReset chan struct{}            // wakes up runTurnClock when the deadline moved
+	deadline   time.Time                // when the active player runs out of time; zero when not timed
+	penalty    bool                     // the deadline is a turn limit or time bank, not the inactivity timeout
+	turnOf     *game.Player             // player whose turn the clock is timing
+	turnStart  time.Time                // zero while the clock is stopped
+	banks      map[string]time.Duration // time bank left per player id, as of the start of the running turn
+
+	spectators  map[string]bool // connected spectators by id, true for those who get the full reveal
+	reveals     []reveal        // full reveals waiting for the reveal delay to pass, oldest first
+	wakeReveals chan struct{}   // wakes up runReveals when a reveal was queued
+}
+
+func NewGame(rules game.Rules, seed int64) *Game {
+	return &Game{
+		State:       engine.NewState(rules, seed),
+		GameStarted: false,
+		Network:     *NewNetwork(),
+		seqs:        make(map[string]int),
+		away:        make(map[string]chan struct{}),
+		bots:        make(map[string]bot.Strategy),
+		timeouts:    make(map[string]int),
+		clock:       systemClock{},
+		clockReset:  make(chan struct{}, 1),
+		banks:       make(map[string]time.Duration),
+		spectators:  make(map[string]bool),
+		wakeReveals: make(chan struct{}, 1),
+	}
 }
 
+// AddPlayer seats player. A name that is already taken in the room gets a
+// number appended.
 func (g *Game) AddPlayer(player *game.Player) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	player.AddCards(g.GameDeck.Cut(7))
-	g.Players = append(g.Players, player)
+	player.Name = g.uniqueName(player.Name)
+	g.State.AddPlayer(player)
 }
 
-func (g *Game) NextTurn() {
-	g.ActivePlayer.Drawn = false
-	//check for Game winner
-	if g.ActivePlayer.Deck.NumberOfCards() == 0 {
-		g.declareWinner(g.ActivePlayer)
+func (g *Game) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.nameTaken(unique); i++ {
+		// The number must not take the name over the length limit.
+		suffix := fmt.Sprintf(" %d", i)
+		base := []rune(name)
+		if keep := game.MaxNameLength - len(suffix); len(base) > keep {
+			base = base[:keep]
+		}
+		unique = strings.TrimSpace(string(base)) + suffix
 	}
-	//Check for UNO
-	if g.ActivePlayer.Deck.NumberOfCards() == 1 {
-		g.checkforUNO(g.ActivePlayer)
+	return unique
+}
+
+func (g *Game) nameTaken(name string) bool {
+	for _, player := range g.Players {
+		if strings.EqualFold(player.Name, name) {
+			return true
+		}
 	}
+	return false
+}
 
-	integerDirection := convertDirectionToInteger(g.GameDirection)
+func (g *Game) Start() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.start()
+}
 
-	nextTurn := (g.CurrentTurn + integerDirection) % len(g.Players)
-	if nextTurn < 0 {
-		nextTurn += len(g.Players)
+// startIfFull starts the game once every seat is taken and reports whether
+// it did.
+func (g *Game) startIfFull() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.GameStarted || len(g.Players) < g.Room.maxPlayers {
+		return false
 	}
-
-	g.SetActivePlayer(nextTurn)
-	g.Network.SendInfoMessage(g.ActivePlayer, "It is your turn.")
+	g.start()
+	return true
 }
-func (g *Game) Start() {
+
+func (g *Game) start() {
 	// Start the first player's turn
 	g.GameFirstMove = true
-	g.SetActivePlayer(0)
+	events := g.State.Start()
 	g.GameStarted = true
+	g.fillTimeBanks()
+	g.updateTurnClock(true)
+	go g.runTurnClock()
+	if g.Room.settings.RevealDelay > 0 {
+		go g.runReveals()
+		g.queueReveal()
+	}
+	g.publish(events)
+	g.autoPlay()
 }
 
-func (g *Game) PlayCard(p *game.Player, index int, newColor string) {
+// apply runs a move through the rules engine and tells the players what
+// happened. It returns the resulting state version; a rejected move leaves the
+// game untouched and is returned as the error.
+func (g *Game) apply(p *game.Player, move engine.Move) (int, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	card := p.Deck.Cards[index]
-
-	switch {
-	case card.Type() == "action-card-no-color":
-		parsedColor, err := color.ParseColor(newColor)
-		if err != nil {
-			g.Network.SendInfoMessage(p, "Invalid color. Try again.")
-			return
-		}
-		g.SetTopCard(card, parsedColor)
-		g.DisposedGameDeck.AddCard(p.Deck.RemoveCard(index))
-		g.Network.BroadcastInfoMessage(fmt.Sprintf("%s played %s and changed the color to %s", p.Name, card.LogCard(), newColor))
+	if g.paused() {
+		return g.Version, errPaused
+	}
+	from := g.Version
+	events, err := g.State.Apply(p, move)
+	if err != nil {
+		return g.Version, err
+	}
+	g.applied(from, events)
+	g.autoPlay()
+	return g.Version, nil
+}
 
-		if card.Rank == rank.DRAW_4 {
-			g.PerformDrawAction(g.getNextPlayer(), 4)
-			g.skipNextTurn()
+// applied tells the players about the events of a move the engine accepted
+// at version from, and restarts the turn clock. It reports whether the move
+// handed the turn to the next player. It must be called with g.mu held.
+func (g *Game) applied(from int, events []engine.Event) bool {
+	newTurn := false
+	for _, event := range events {
+		if _, ok := event.(engine.TurnChanged); ok {
+			newTurn = true
 		}
-		g.NextTurn()
-	case g.IsValidMove(card, p):
-		if card.Type() == "action-card" {
-			g.dealwithActionCards(card)
-		}
-		g.SetTopCard(card)
-		g.Network.BroadcastInfoMessage(fmt.Sprintf("%s played %s", p.Name, card.LogCard()))
-		g.DisposedGameDeck.AddCard(p.Deck.RemoveCard(index))
-		g.NextTurn()
-
-	default:
-		g.Network.SendInfoMessage(p, "Invalid move. Wrong card or wrong player. Try again.")
 	}
+	g.recordLastAction(events)
+	g.updateTurnClock(newTurn)
+	g.publish(events)
+	g.sendPatches(from, events)
+	g.queueReveal()
+	if g.Phase == engine.PhaseGameOver {
+		// Only now that the winning move's patch is queued.
+		g.closeConnections()
+	}
+	return newTurn
 }
 
-
-func(g *Game) SetActivePlayer(index int) {
-	g.CurrentTurn = index
-	g.ActivePlayer = g.Players[index]
+func (g *Game) version() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.Version
 }
 
-func (g *Game) SetTopCard(card game.Card, color ...color.Color) {
-	if card.Type() == "action-card-no-color" && len(color) > 0 {
-		g.TopCard = card
-		g.TopColor = color[0]
-	} else {
-		g.TopCard = card
-		g.TopColor = card.Color
+// acceptSeq records seq as the last command handled for p. It fails when seq
+// is not higher than that command's, and returns the last seq either way.
+func (g *Game) acceptSeq(p *game.Player, seq int) (int, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	last := g.seqs[p.ID]
+	if seq <= last {
+		return last, false
 	}
+	g.seqs[p.ID] = seq
+	return last, true
 }
 
-func (g *Game) IsValidMove(playedCard game.Card, player *game.Player) bool {
-	if player != g.ActivePlayer {
-		return false
-	}
-	if g.TopCard.Type() == "action-card-no-color" {
-		return playedCard.Color == g.TopColor
-	}
-	// If the played card matches the color or rank of the top card, it's a valid move
-	return playedCard.IsSameColor(g.TopCard) || playedCard.IsSameRank(g.TopCard)
+func (g *Game) ack(p *game.Player, cmd commands.BaseCommand, accepted bool, version int) {
+	dto := dtos.AckDTO{Seq: cmd.Seq, CorrelationID: cmd.ID, Accepted: accepted, Version: version}
+	g.Network.SendMessage(p, dto.Serialize())
 }
 
-func (g *Game) PerformDrawAction(player *game.Player, card_count int) {
-	cardsDrawn := g.GameDeck.Cut(card_count)
-	player.AddCards(cardsDrawn)
-	for _, card := range cardsDrawn {
-		g.Network.SendInfoMessage(player, fmt.Sprintf("%s Drew %s", player.Name, card.LogCard()))
-	}
-	g.Network.SendInfoMessage(player, fmt.Sprintf("%s Drew Drew %d cards  ", player.Name, card_count))
-
+// reject answers cmd with an ErrorDTO followed by a negative ack.
+func (g *Game) reject(p *game.Player, cmd commands.BaseCommand, code errcode.Code, message string) {
+	dto := dtos.ErrorDTO{Code: code, Message: message, CorrelationID: cmd.ID}
+	g.Network.SendMessage(p, dto.Serialize())
+	g.ack(p, cmd, false, g.version())
 }
 
-func (g *Game) ShuffleDiscardPileToDeck() {
-	if len(g.DisposedGameDeck.Deck.Cards) > 0 {
-		// Shuffle the discard pile
-		rand.Seed(time.Now().UnixNano())
-		rand.Shuffle(len(g.DisposedGameDeck.Deck.Cards), func(i, j int) {
-			g.DisposedGameDeck.Deck.Cards[i], g.DisposedGameDeck.Deck.Cards[j] = g.DisposedGameDeck.Deck.Cards[j], g.DisposedGameDeck.Deck.Cards[i]
-		})
-
-		// Create a new deck from the shuffled discard pile
-		g.GameDeck.Deck.Cards = g.DisposedGameDeck.Deck.Cards
-		g.GameDeck.Deck.Counter = g.DisposedGameDeck.Deck.Counter
-
-		// Clear the discard pile
-		g.DisposedGameDeck.Deck.Cards = make([]game.Card, 0)
-	}
+// rejectMove tells p why the engine refused their move.
+func (g *Game) rejectMove(p *game.Player, cmd commands.BaseCommand, err error) {
+	g.reject(p, cmd, moveErrorCode(err), moveErrorMessage(err))
 }
 
-// reverseGameDirection reverses the game direction
-func (g *Game) reverseGameDirection() {
-	g.GameDirection = !g.GameDirection
+func moveErrorCode(err error) errcode.Code {
+	switch {
+	case errors.Is(err, engine.ErrNotYourTurn):
+		return errcode.NOT_YOUR_TURN
+	case errors.Is(err, engine.ErrIllegalCard):
+		return errcode.ILLEGAL_CARD
+	case errors.Is(err, engine.ErrUnknownCard):
+		return errcode.UNKNOWN_CARD
+	case errors.Is(err, engine.ErrCardNotOwned):
+		return errcode.CARD_NOT_OWNED
+	case errors.Is(err, engine.ErrBadColor):
+		return errcode.BAD_COLOR
+	case errors.Is(err, engine.ErrBadTarget):
+		return errcode.BAD_TARGET
+	case errors.Is(err, engine.ErrJumpInTooLate):
+		return errcode.JUMP_IN_TOO_LATE
+	case errors.Is(err, engine.ErrGameOver):
+		return errcode.GAME_OVER
+	case errors.Is(err, errPaused):
+		return errcode.PAUSED
+	case errors.Is(err, errNotHost):
+		return errcode.NOT_HOST
+	case errors.Is(err, errRoomFull):
+		return errcode.ROOM_FULL
+	case errors.Is(err, errGameStarted):
+		return errcode.GAME_STARTED
+	case errors.Is(err, bot.ErrUnknownStrategy):
+		return errcode.UNKNOWN_STRATEGY
+	default:
+		return errcode.ILLEGAL_MOVE
+	}
 }
 
-// skipNextTurn skips the next player's turn
-func (g *Game) skipNextTurn() {
-	nextPlayer := g.getNextPlayer()
-	g.Network.SendInfoMessage(nextPlayer, "Your turn is SKIPPED")
-
-	g.switchtoNextPlayer()
+func moveErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, engine.ErrBadColor):
+		return "Invalid color. Try again."
+	case errors.Is(err, engine.ErrJumpInTooLate):
+		return "Too late, another player jumped in first."
+	case errors.Is(err, engine.ErrUnknownCard), errors.Is(err, engine.ErrCardNotOwned):
+		return "Invalid move. You do not hold this card. Try again."
+	case errors.Is(err, engine.ErrIllegalCard), errors.Is(err, engine.ErrNotYourTurn):
+		return "Invalid move. Wrong card or wrong player. Try again."
+	default:
+		return "Invalid move: " + err.Error()
+	}
 }
 
 // declareWinner declares the winner of the game
@@ -197,26 +258,16 @@ func (g *Game) declareWinner(winner *game.Player) {
 	}
 	for _, p := range g.Players {
 		g.Network.SendInfoMessage(p, fmt.Sprintf("GAME OVER  %s ,CLOSING CONNECTION ", winner.Name))
-		g.Network.CloseConnection(p)
 	}
 	// Perform any necessary  end-game animation with bubbleTea
 }
-func (g *Game) checkforUNO(player *game.Player) {
-	for _, p := range g.Players {
-		g.Network.SendInfoMessage(p, fmt.Sprintf("UNO !!!! by %s ", player.Name))
-	}
-}
-
-// getNextPlayer returns the next player based on the game direction
-func (g *Game) getNextPlayer() *game.Player {
-	integerDirection := convertDirectionToInteger(g.GameDirection)
-
-	nextTurn := (g.CurrentTurn + integerDirection) % len(g.Players)
-	if nextTurn < 0 {
-		nextTurn += len(g.Players)
 
+// closeConnections closes the connections of the players once everything
+// sent to them has been written.
+func (g *Game) closeConnections() {
+	for _, p := range g.Players {
+		g.Network.CloseConnection(p)
 	}
-	return g.Players[nextTurn]
 }
 
 func (g *Game) getAllPlayers() []string {
@@ -227,62 +278,143 @@ func (g *Game) getAllPlayers() []string {
 	return playerNames
 }
 
-func (g *Game) dealwithActionCards(card game.Card) {
-	cardType := card.Rank
-	switch cardType {
-	case "skip":
-		g.skipNextTurn()
-	case "draw_2":
-		g.PerformDrawAction(g.getNextPlayer(), 2)
-		g.skipNextTurn()
-	case "reverse":
-		log.Println("game direction will be reversed")
-		log.Println("Current Turn: ", g.ActivePlayer.Name)
-		g.reverseGameDirection()
-		log.Println("Game direction reversed now")
-	default:
-		// Handle unexpected card types here, e.g., log an error
-		fmt.Println("Unexpected card type:", cardType)
+// findPlayer returns the seated player with the given id, or nil.
+func (g *Game) findPlayer(id string) *game.Player {
+	for _, player := range g.Players {
+		if player.ID == id {
+			return player
+		}
+	}
+	return nil
+}
+
+// getUnoCalls returns whether each player called UNO, keyed by player id.
+func (g *Game) getUnoCalls() map[string]bool {
+	unoCalls := make(map[string]bool)
+	for _, player := range g.Players {
+		unoCalls[player.ID] = g.UnoCalled[player]
 	}
+	return unoCalls
 }
 
-func (g *Game) switchtoNextPlayer() {
-	integerDirection := convertDirectionToInteger(g.GameDirection)
-	g.CurrentTurn = (g.CurrentTurn + integerDirection) % len(g.Players)
-	if g.CurrentTurn < 0 {
-		g.CurrentTurn += len(g.Players)
+// legalMoves returns the moves p may make, as worked out by the engine.
+func (g *Game) legalMoves(p *game.Player) dtos.LegalMovesDTO {
+	moves := g.Legal(p)
+	return dtos.LegalMovesDTO{
+		PlayableCards: moves.Cards,
+		Draw:          moves.Draw,
+		Pass:          moves.Pass,
+		ChooseColor:   moves.ChooseColor,
+		Challenge:     moves.Challenge,
+		SwapHands:     moves.SwapHands,
+		CallUno:       moves.CallUno,
+		CatchUno:      moves.CatchUno,
+	}
+}
 
+// getSeats returns the public view of every seat in seat order.
+func (g *Game) getSeats() []dtos.SeatState {
+	seats := make([]dtos.SeatState, 0, len(g.Players))
+	for _, player := range g.Players {
+		_, connected := g.Network.GetClient(player.ID)
+		_, isBot := g.bots[player.ID]
+		seats = append(seats, dtos.SeatState{
+			ID:        player.ID,
+			Name:      player.Name,
+			Cards:     player.NumberOfCards(),
+			UnoCalled: g.UnoCalled[player],
+			Connected: connected || isBot,
+			Bot:       isBot,
+		})
 	}
+	return seats
 }
 
+// HandleCommand decodes a command sent by player and runs it. Commands of one
+// player have to be handled one at a time, in the order they arrived. Every
+// command is acknowledged, and a bad one is answered with an ErrorDTO.
 func (g *Game) HandleCommand(data []byte, player *game.Player) {
-	cmd, err := commands.DeserializeCommand(data)
+	var base commands.BaseCommand
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic while handling %s command from %s: %v", base.Type, player.Name, r)
+			g.reject(player, base, errcode.INTERNAL, "The server failed to handle the command.")
+		}
+	}()
+
+	base, cmd, err := commands.DeserializeCommand(data)
 	if err != nil {
-		log.Fatalf("Failed to deserialize command: %v", err)
+		code := errcode.MALFORMED
+		if errors.Is(err, commands.ErrUnknownCommand) {
+			code = errcode.UNKNOWN_COMMAND
+		}
+		g.reject(player, base, code, err.Error())
+		return
+	}
+	if last, ok := g.acceptSeq(player, base.Seq); !ok {
+		g.reject(player, base, errcode.OUT_OF_ORDER, fmt.Sprintf("seq %d is not higher than the last command's seq %d", base.Seq, last))
+		return
 	}
+	g.playerActed(player)
 
 	switch c := cmd.(type) {
 	case *commands.SyncCommand:
-		g.SyncPlayer(player)
-	case *commands.PlayCardCommand:
-		if g.ActivePlayer == player {
-			g.PlayCard(player, c.CardIndex, c.NewColor)
+		if c.Version != g.version() {
+			g.SyncPlayer(player)
 		}
-		g.SyncAllPlayers()
-	case *commands.DrawCardComamnd:
-		if (g.ActivePlayer == player && player.Drawn == false){
-			g.PerformDrawAction(player, 1)
-			player.Drawn = true
+		g.ack(player, base, true, g.version())
+		return
+	case *commands.AddBotCommand:
+		if err := g.addBot(player, c.Strategy); err != nil {
+			g.rejectMove(player, base, err)
+			return
 		}
-		if (!g.ActivePlayer.HasPlayableCard(g.TopCard)) {
-			g.NextTurn()
+		g.ack(player, base, true, g.version())
+		if g.startIfFull() {
+			g.SyncAllPlayers()
+			g.Network.BroadcastInfoMessage("All players have joined. Game has started.")
 		}
-		g.SyncAllPlayers()
-	default:
-		log.Printf("Unknown command type: %T", c)
+		return
+	}
 
+	move := g.toMove(cmd)
+	if move == nil {
+		log.Printf("Unknown command type: %T", cmd)
+		g.reject(player, base, errcode.UNKNOWN_COMMAND, fmt.Sprintf("unsupported command type: %s", base.Type))
+		return
+	}
+	version, err := g.apply(player, move)
+	if err != nil {
+		g.rejectMove(player, base, err)
+		return
 	}
+	g.ack(player, base, true, version)
+}
 
+// toMove turns a command into the move it asks the engine for, or nil when it
+// is not a move.
+func (g *Game) toMove(cmd interface{}) engine.Move {
+	switch c := cmd.(type) {
+	case *commands.PlayCardCommand:
+		return engine.PlayCard{CardID: c.CardID, NewColor: color.Color(c.NewColor), CallUno: c.CallUno}
+	case *commands.DrawCardComamnd:
+		return engine.DrawCard{}
+	case *commands.PassCommand:
+		return engine.Pass{}
+	case *commands.ChooseColorCommand:
+		return engine.ChooseColor{Color: color.Color(c.Color)}
+	case *commands.ChallengeDraw4Command:
+		return engine.ChallengeDraw4{}
+	case *commands.AcceptDraw4Command:
+		return engine.AcceptDraw4{}
+	case *commands.CallUnoCommand:
+		return engine.CallUno{}
+	case *commands.CatchUnoCommand:
+		return engine.CatchUno{}
+	case *commands.SwapHandsCommand:
+		return engine.SwapHands{Target: g.findPlayer(c.Target)}
+	}
+	return nil
 }
 
 func (g *Game) SyncPlayer(p *game.Player) {
@@ -291,50 +423,82 @@ func (g *Game) SyncPlayer(p *game.Player) {
 		return
 	}
 
-	activePlayer := g.ActivePlayer
-	if activePlayer == nil {
-		log.Printf("ActivePlayer is nil; cannot sync")
+	// Hands can move between players, so the snapshot is taken under the game lock.
+	g.mu.Lock()
+	message := g.syncMessage(p)
+	g.mu.Unlock()
+	if message == nil {
 		return
 	}
 
-	if activePlayer.Name == "" {
-		log.Printf("ActivePlayer's Name is empty; cannot sync")
-		return
+	if err := g.Network.SendMessage(p, message); err != nil {
+		log.Printf("Failed to sync player %s: %v", p.Name, err)
 	}
+}
 
-	dto := dtos.SyncDTO{
-		Player: *p,
-		Game: dtos.GameState{
-			TopCard:  g.TopCard,
-			TopColor: g.TopColor,
-			Turn:     activePlayer.Name,
-			Reverse:  g.GameDirection,
-		},
-		Room: dtos.RoomState{
-			Players:    g.getAllPlayers(),
-			RoomId:     g.Room.id,
-			MaxPlayers: g.Room.maxPlayers,
-		},
-	}
-
-	conn, ok := g.Network.clients[*p]
+// syncMessage serializes the state of the game as seen by p.
+func (g *Game) syncMessage(p *game.Player) []byte {
+	dto, ok := g.syncView(p)
 	if !ok {
-		log.Printf("Player %s not found in network clients", p.Name)
-		return
+		return nil
 	}
+	return dto.Serialize()
+}
 
-	lock, ok := g.Network.locks[*p]
+// syncView returns the state of the game as seen by p. It fails until the
+// game has started.
+func (g *Game) syncView(p *game.Player) (dtos.SyncDTO, bool) {
+	state, ok := g.gameState()
 	if !ok {
-		log.Printf("No mutex found for player %s", p.Name)
-		return
+		return dtos.SyncDTO{}, false
+	}
+	dto := dtos.SyncDTO{
+		Player: *p,
+		Moves:  g.legalMoves(p),
+		Game:   state,
+		Room:   g.roomState(),
 	}
+	return dto, true
+}
 
-	lock.Lock()
-	defer lock.Unlock()
+// gameState returns what everybody at the table can see of the game. It
+// fails until the game has started.
+func (g *Game) gameState() (dtos.GameState, bool) {
+	activePlayer := g.ActivePlayer
+	if activePlayer == nil {
+		log.Printf("ActivePlayer is nil; cannot sync")
+		return dtos.GameState{}, false
+	}
 
-	err := conn.WriteMessage(websocket.TextMessage, dto.Serialize())
-	if err != nil {
-		log.Printf("Failed to sync player %s: %v", p.Name, err)
+	if activePlayer.Name == "" {
+		log.Printf("ActivePlayer's Name is empty; cannot sync")
+		return dtos.GameState{}, false
+	}
+
+	state := dtos.GameState{
+		Version:     g.Version,
+		TopCard:     g.TopCard,
+		TopColor:    g.TopColor,
+		Turn:        activePlayer.ID,
+		Reverse:     g.GameDirection,
+		PendingDraw: g.PendingDraw,
+		Phase:       g.Phase.String(),
+		UnoCalled:   g.getUnoCalls(),
+		Seats:       g.getSeats(),
+		DrawPile:    g.GameDeck.NumberOfCards(),
+		DiscardPile: g.DisposedGameDeck.NumberOfCards(),
+		LastAction:  g.lastAction,
+		Deadline:    g.deadlineMillis(),
+		TimeBanks:   g.timeBanks(),
+	}
+	return state, true
+}
+
+func (g *Game) roomState() dtos.RoomState {
+	return dtos.RoomState{
+		Players:    g.getAllPlayers(),
+		RoomId:     g.Room.id,
+		MaxPlayers: g.Room.maxPlayers,
 	}
 }
 
@@ -351,4 +515,5 @@ func (g *Game) SyncAllPlayers() {
 	}
 
 	wg.Wait()
+	g.syncSpectators()
 }
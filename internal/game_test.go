@@ -0,0 +1,351 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uno/internal/engine"
+	"uno/models/constants/color"
+	"uno/models/constants/errcode"
+	"uno/models/constants/rank"
+	"uno/models/dtos"
+	"uno/models/game"
+
+	"github.com/gorilla/websocket"
+)
+
+// connectClient binds a websocket to the client id in g's network and returns
+// the other end of it, from which the test reads what the server sent.
+func connectClient(t *testing.T, g *Game, id string) *websocket.Conn {
+	t.Helper()
+	bound := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := g.Network.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		g.Network.AddClient(id, conn)
+		close(bound)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	<-bound
+	return client
+}
+
+// readMessage reads the next message sent to client and decodes its obj into v.
+func readMessage(t *testing.T, client *websocket.Conn, wantType string, v interface{}) {
+	t.Helper()
+	if got := readAnyMessage(t, client, v); got != wantType {
+		t.Fatalf("got a %s message, want %s", got, wantType)
+	}
+}
+
+// readAnyMessage reads the next message sent to client, decodes its obj into
+// v and returns its type.
+func readAnyMessage(t *testing.T, client *websocket.Conn, v interface{}) string {
+	t.Helper()
+	client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := client.ReadMessage()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var message struct {
+		Type string          `json:"type"`
+		Obj  json.RawMessage `json:"obj"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	if err := json.Unmarshal(message.Obj, v); err != nil {
+		t.Fatalf("decode %s message %s: %v", message.Type, message.Obj, err)
+	}
+	return message.Type
+}
+
+// readUntil skips the messages sent to client up to the next one of wantType,
+// whose obj it decodes into v.
+func readUntil(t *testing.T, client *websocket.Conn, wantType string, v interface{}) {
+	t.Helper()
+	for {
+		var obj json.RawMessage
+		if readAnyMessage(t, client, &obj) == wantType {
+			if err := json.Unmarshal(obj, v); err != nil {
+				t.Fatalf("decode %s message %s: %v", wantType, obj, err)
+			}
+			return
+		}
+	}
+}
+
+func TestHandleCommandRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              string
+		wantCode          errcode.Code
+		wantCorrelationID string
+	}{
+		{"malformed JSON", `{"id": "1", "seq": 1, "type": "PLAY_CARD"`, errcode.MALFORMED, ""},
+		{"unknown type", `{"id": "2", "seq": 1, "type": "FLIP_TABLE"}`, errcode.UNKNOWN_COMMAND, "2"},
+		{"wrong obj shape", `{"id": "3", "seq": 1, "type": "PLAY_CARD", "obj": {"card_id": "seven"}}`, errcode.MALFORMED, "3"},
+		{"obj is not an object", `{"id": "4", "seq": 1, "type": "PLAY_CARD", "obj": [1, 2]}`, errcode.MALFORMED, "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := NewRoom(2, game.DefaultRoomSettings(), 1)
+			t.Cleanup(func() { delete(rooms, room.id) })
+			g := &room.game
+			player := game.NewPlayer("Alice")
+			player.ID = "alice"
+			g.AddPlayer(player)
+			client := connectClient(t, g, player.ID)
+
+			g.HandleCommand([]byte(tt.data), player)
+
+			var rejected dtos.ErrorDTO
+			readMessage(t, client, "error", &rejected)
+			if rejected.Code != tt.wantCode {
+				t.Errorf("code = %s, want %s", rejected.Code, tt.wantCode)
+			}
+			if rejected.CorrelationID != tt.wantCorrelationID {
+				t.Errorf("correlation id = %q, want %q", rejected.CorrelationID, tt.wantCorrelationID)
+			}
+			var ack dtos.AckDTO
+			readMessage(t, client, "ack", &ack)
+			if ack.Accepted || ack.CorrelationID != tt.wantCorrelationID {
+				t.Errorf("ack = %+v, want a rejection of %q", ack, tt.wantCorrelationID)
+			}
+		})
+	}
+}
+
+func TestGameOverReachesPlayers(t *testing.T) {
+	g, _ := newTimedGame(t, game.DefaultRoomSettings())
+	clients := map[string]*websocket.Conn{}
+	for _, id := range []string{"p1", "p2"} {
+		clients[id] = connectClient(t, g, id)
+	}
+
+	g.mu.Lock()
+	winner := g.ActivePlayer
+	g.SetTopCard(game.Card{Rank: rank.FIVE, Color: color.RED})
+	winner.Deck.Cards = []game.Card{{ID: 1, Rank: rank.FIVE, Color: color.RED}}
+	g.mu.Unlock()
+	version, err := g.apply(winner, engine.PlayCard{CardID: 1})
+	if err != nil {
+		t.Fatalf("PlayCard: %v", err)
+	}
+
+	for id, client := range clients {
+		var won, over bool
+		var patch *dtos.PatchDTO
+		client.SetReadDeadline(time.Now().Add(2 * time.Second))
+		for {
+			_, data, err := client.ReadMessage()
+			if err != nil {
+				if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+					t.Errorf("%s: connection ended with %v, want a normal close", id, err)
+				}
+				break
+			}
+			var message struct {
+				Type string          `json:"type"`
+				Obj  json.RawMessage `json:"obj"`
+			}
+			if err := json.Unmarshal(data, &message); err != nil {
+				t.Fatalf("decode %s: %v", data, err)
+			}
+			switch message.Type {
+			case "info":
+				var info dtos.InfoDTO
+				json.Unmarshal(message.Obj, &info)
+				won = won || strings.Contains(info.Message, "HAS WON")
+				over = over || strings.Contains(info.Message, "GAME OVER")
+			case "patch":
+				patch = &dtos.PatchDTO{}
+				json.Unmarshal(message.Obj, patch)
+			}
+		}
+		if !won || !over {
+			t.Errorf("%s: got the winner %v and the game over %v, want both", id, won, over)
+		}
+		if patch == nil || patch.Version != version || patch.Phase != engine.PhaseGameOver.String() {
+			t.Errorf("%s: last patch = %+v, want the winning move at version %d", id, patch, version)
+		}
+	}
+}
+
+func TestHandleCommandSeq(t *testing.T) {
+	g, _ := newTimedGame(t, game.DefaultRoomSettings())
+	p := g.ActivePlayer
+	client := connectClient(t, g, p.ID)
+	sync := func(seq int) []byte {
+		return []byte(fmt.Sprintf(`{"id": "%d", "seq": %d, "type": "SYNC_GAME_STATE", "obj": {"version": %d}}`, seq, seq, g.version()))
+	}
+
+	g.HandleCommand([]byte(`{"id": "draw", "seq": 5, "type": "DRAW_CARD"}`), p)
+	var ack dtos.AckDTO
+	readUntil(t, client, "ack", &ack)
+	if !ack.Accepted || ack.Seq != 5 || ack.CorrelationID != "draw" {
+		t.Errorf("ack = %+v, want draw accepted at seq 5", ack)
+	}
+	if want := g.version(); ack.Version != want || want == 0 {
+		t.Errorf("ack version = %d, want the version %d after the move", ack.Version, want)
+	}
+
+	for _, seq := range []int{5, 4} {
+		g.HandleCommand(sync(seq), p)
+		var rejected dtos.ErrorDTO
+		readUntil(t, client, "error", &rejected)
+		if rejected.Code != errcode.OUT_OF_ORDER {
+			t.Errorf("seq %d: code = %s, want %s", seq, rejected.Code, errcode.OUT_OF_ORDER)
+		}
+		readUntil(t, client, "ack", &ack)
+		if ack.Accepted || ack.Seq != seq {
+			t.Errorf("seq %d: ack = %+v, want a rejection", seq, ack)
+		}
+	}
+
+	g.HandleCommand(sync(6), p)
+	readUntil(t, client, "ack", &ack)
+	if !ack.Accepted || ack.Seq != 6 {
+		t.Errorf("seq 6: ack = %+v, want it accepted", ack)
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	long := strings.Repeat("a", game.MaxNameLength)
+	tests := []struct {
+		taken []string
+		name  string
+		want  string
+	}{
+		{nil, "Bob", "Bob"},
+		{[]string{"Bob"}, "Bob", "Bob 2"},
+		{[]string{"bob", "Bob 2"}, "Bob", "Bob 3"},
+		{[]string{long}, long, strings.Repeat("a", game.MaxNameLength-2) + " 2"},
+		{[]string{"abcdefghijklmnopq st"}, "abcdefghijklmnopq st", "abcdefghijklmnopq 2"},
+	}
+
+	for _, tt := range tests {
+		g := NewGame(game.DefaultRoomSettings().Rules, 1)
+		for _, name := range tt.taken {
+			g.State.AddPlayer(game.NewPlayer(name))
+		}
+		got := g.uniqueName(tt.name)
+		if got != tt.want {
+			t.Errorf("uniqueName(%q) with %v taken = %q, want %q", tt.name, tt.taken, got, tt.want)
+		}
+		if n := len([]rune(got)); n > game.MaxNameLength {
+			t.Errorf("uniqueName(%q) = %q is %d characters long", tt.name, got, n)
+		}
+	}
+}
+
+// handKeys returns the paths of the card lists in a decoded JSON value.
+func handKeys(path string, v interface{}) []string {
+	var found []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if _, isList := child.([]interface{}); isList && strings.EqualFold(key, "cards") {
+				found = append(found, path+"."+key)
+			}
+			found = append(found, handKeys(path+"."+key, child)...)
+		}
+	case []interface{}:
+		for i, child := range v {
+			found = append(found, handKeys(fmt.Sprintf("%s[%d]", path, i), child)...)
+		}
+	}
+	return found
+}
+
+func TestSyncShowsTable(t *testing.T) {
+	room := NewRoom(3, game.DefaultRoomSettings(), 1)
+	t.Cleanup(func() { delete(rooms, room.id) })
+	g := &room.game
+	for _, id := range []string{"alice", "bob"} {
+		player := game.NewPlayer(id)
+		player.ID = id
+		g.AddPlayer(player)
+	}
+	g.mu.Lock()
+	if err := g.seatBot("random"); err != nil {
+		t.Fatalf("seatBot: %v", err)
+	}
+	g.mu.Unlock()
+	connectClient(t, g, "alice")
+	g.Start()
+
+	g.mu.Lock()
+	p := g.ActivePlayer
+	g.mu.Unlock()
+	if _, err := g.apply(p, engine.DrawCard{}); err != nil {
+		t.Fatalf("DrawCard: %v", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	alice := g.findPlayer("alice")
+	dto, ok := g.syncView(alice)
+	if !ok {
+		t.Fatalf("no sync after the start")
+	}
+	state := dto.Game
+	if len(state.Seats) != len(g.Players) {
+		t.Fatalf("got %d seats, want %d", len(state.Seats), len(g.Players))
+	}
+	for i, seat := range state.Seats {
+		player := g.Players[i]
+		_, isBot := g.bots[player.ID]
+		want := dtos.SeatState{
+			ID:        player.ID,
+			Name:      player.Name,
+			Cards:     player.NumberOfCards(),
+			UnoCalled: g.UnoCalled[player],
+			Connected: player.ID == "alice" || isBot,
+			Bot:       isBot,
+		}
+		if seat != want {
+			t.Errorf("seat %d = %+v, want %+v", i, seat, want)
+		}
+	}
+	total := state.DrawPile + state.DiscardPile
+	for _, seat := range state.Seats {
+		total += seat.Cards
+	}
+	if total != game.DeckSize {
+		t.Errorf("the table shows %d cards, want %d", total, game.DeckSize)
+	}
+	if state.DrawPile != g.GameDeck.NumberOfCards() || state.DiscardPile != g.DisposedGameDeck.NumberOfCards() {
+		t.Errorf("piles = %d and %d, want %d and %d", state.DrawPile, state.DiscardPile, g.GameDeck.NumberOfCards(), g.DisposedGameDeck.NumberOfCards())
+	}
+	if state.LastAction == nil || state.LastAction.Player == "" {
+		t.Errorf("last action = %+v, want the last move", state.LastAction)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(dto.Serialize(), &decoded); err != nil {
+		t.Fatalf("decode sync: %v", err)
+	}
+	obj := decoded["obj"].(map[string]interface{})
+	if hands := handKeys("sync", obj); len(hands) != 1 {
+		t.Fatalf("card lists in the sync of alice = %v, want just her hand", hands)
+	}
+	delete(obj, "player")
+	if hands := handKeys("sync", obj); len(hands) > 0 {
+		t.Errorf("the sync of alice shows cards outside her own hand at %v", hands)
+	}
+}
@@ -1,11 +1,18 @@
 package internal
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"log"
 	"math/rand"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"uno/internal/bot"
 	"uno/models/dtos"
 	"uno/models/game"
 
@@ -17,25 +24,32 @@ type Room struct {
 	id         int
 	game       Game
 	maxPlayers int
+	settings   game.RoomSettings
 }
 
-func NewRoom(maxPlayers int) *Room {
+// NewRoom creates a room whose game is shuffled from seed.
+func NewRoom(maxPlayers int, settings game.RoomSettings, seed int64) *Room {
 	roomId := generateID()
 
 	r := &Room{
 		id:         roomId,
-		game:       *NewGame(),
+		game:       *NewGame(settings.Rules, seed),
 		maxPlayers: maxPlayers,
+		settings:   settings,
 	}
 	r.game.Room = r
+	// Bots are seated and announced before anybody connects, so the room
+	// broadcasts from the start.
+	go r.game.Network.BroadcastMessages()
 	rooms[roomId] = r
+	log.Printf("Room %d created with seed %d", roomId, seed)
 	return r
 }
 
 const (
 	ROOM_START_INDEX = 1000
 	ROOM_END_INDEX   = 9999
-	MAX_ROOMS = ROOM_END_INDEX - ROOM_START_INDEX
+	MAX_ROOMS        = ROOM_END_INDEX - ROOM_START_INDEX
 )
 
 var rooms map[int]*Room
@@ -57,6 +71,11 @@ func CreateRoomHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Missing player_name parameter", http.StatusBadRequest)
 		return
 	}
+	playerName, err := game.ValidatePlayerName(playerName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	maxPlayersStr := r.URL.Query().Get("max_players")
 	if maxPlayersStr == "" {
@@ -69,22 +88,64 @@ func CreateRoomHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid max_players parameter", http.StatusBadRequest)
 		return
 	}
-	room := NewRoom(maxPlayers)
+	if maxPlayers < 2 {
+		http.Error(w, "max_players must be at least 2", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := parseRoomSettings(r)
+	if err != nil {
+		http.Error(w, "Invalid settings parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if settings.HandSize*maxPlayers >= game.DeckSize {
+		http.Error(w, "Not enough cards for hand_size and max_players", http.StatusBadRequest)
+		return
+	}
+	botStrategies, err := parseBots(r, maxPlayers)
+	if err != nil {
+		http.Error(w, "Invalid bots parameter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	seed := time.Now().UnixNano()
+	if seedStr := r.URL.Query().Get("seed"); seedStr != "" {
+		seed, err = strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid seed parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	room := NewRoom(maxPlayers, settings, seed)
 
 	game := &room.game
 	player := AddPlayerToRoom(&w, room.id, playerName)
+	for _, strategy := range botStrategies {
+		game.mu.Lock()
+		err := game.seatBot(strategy)
+		game.mu.Unlock()
+		if err != nil {
+			log.Printf("Failed to seat a %s bot in room %d: %v", strategy, room.id, err)
+		}
+	}
 
 	// Respond with the room id
 	conn := UpgradeWebsocket(w, r, room)
-	game.Network.AddClient(*player, conn)
+	if conn == nil {
+		delete(rooms, room.id)
+		return
+	}
+	game.Network.AddClient(player.ID, conn)
 
 	dto := dtos.ConnectionDTO{
-		PlayerName: playerName,
-		RoomID: room.id,
-		MaxPlayers: maxPlayers,
-		Players: room.game.getAllPlayers(),
+		PlayerID:     player.ID,
+		PlayerName:   player.Name,
+		SessionToken: newSession(room.id, player.ID),
+		RoomID:       room.id,
+		MaxPlayers:   maxPlayers,
+		Players:      room.game.getAllPlayers(),
+		Settings:     room.settings,
 	}
-	conn.WriteMessage(websocket.TextMessage, dto.Serialize())
+	game.Network.SendMessage(player, dto.Serialize())
 
 	game.Network.ListenToClient(player, room)
 
@@ -100,29 +161,195 @@ func JoinRoomHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	playerName, err := game.ValidatePlayerName(playerName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Validate room id
 	roomId, err := strconv.Atoi(roomIdStr)
 	if err != nil {
 		http.Error(w, "room_id must be a valid integer", http.StatusBadRequest)
 		return
 	}
-	room := rooms[roomId]
+	room, ok := rooms[roomId]
+	if !ok {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
 	game := &room.game
 	player := AddPlayerToRoom(&w, roomId, playerName)
+	if player == nil {
+		return
+	}
 	conn := UpgradeWebsocket(w, r, room)
-	game.Network.AddClient(*player, conn)
+	if conn == nil {
+		return
+	}
+	game.Network.AddClient(player.ID, conn)
 
 	dto := dtos.ConnectionDTO{
-		PlayerName: playerName,
-		RoomID: room.id,
-		MaxPlayers: room.maxPlayers,
-		Players: room.game.getAllPlayers(),
+		PlayerID:     player.ID,
+		PlayerName:   player.Name,
+		SessionToken: newSession(room.id, player.ID),
+		RoomID:       room.id,
+		MaxPlayers:   room.maxPlayers,
+		Players:      room.game.getAllPlayers(),
+		Settings:     room.settings,
 	}
-	conn.WriteMessage(websocket.TextMessage, dto.Serialize())
+	game.Network.SendMessage(player, dto.Serialize())
 
 	game.Network.ListenToClient(player, room)
 }
 
+// ReconnectHandler binds the seat of the session token to a new websocket and
+// sends the player a full sync.
+func ReconnectHandler(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Error(w, "Missing token parameter", http.StatusBadRequest)
+		return
+	}
+	s, ok := findSession(token)
+	if !ok {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return
+	}
+	room, ok := rooms[s.roomID]
+	if !ok {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	game := &room.game
+	player := game.findPlayer(s.playerID)
+	if player == nil {
+		http.Error(w, "Seat not found", http.StatusNotFound)
+		return
+	}
+
+	conn := UpgradeWebsocket(w, r, room)
+	if conn == nil {
+		return
+	}
+	old, connected := game.Network.GetClient(player.ID)
+	game.Network.AddClient(player.ID, conn)
+	if connected {
+		// The old connection is replaced, its reader ends without leaving the seat.
+		old.Close()
+	}
+
+	dto := dtos.ConnectionDTO{
+		PlayerID:     player.ID,
+		PlayerName:   player.Name,
+		SessionToken: token,
+		RoomID:       room.id,
+		MaxPlayers:   room.maxPlayers,
+		Players:      room.game.getAllPlayers(),
+		Settings:     room.settings,
+	}
+	game.Network.SendMessage(player, dto.Serialize())
+
+	game.playerReturned(player)
+	game.SyncPlayer(player)
+	game.Network.ReadCommands(player, game, conn)
+}
+
+// SpectateHandler lets anybody watch a room without taking a seat. Spectators
+// get the public view of the game and never see a hand, unless they ask for
+// the full reveal of a room that has a reveal delay.
+func SpectateHandler(w http.ResponseWriter, r *http.Request) {
+	roomIdStr := r.URL.Query().Get("room_id")
+	if roomIdStr == "" {
+		http.Error(w, "Missing room_id parameter", http.StatusBadRequest)
+		return
+	}
+	roomId, err := strconv.Atoi(roomIdStr)
+	if err != nil {
+		http.Error(w, "room_id must be a valid integer", http.StatusBadRequest)
+		return
+	}
+	fullReveal := false
+	if revealStr := r.URL.Query().Get("reveal"); revealStr != "" {
+		fullReveal, err = strconv.ParseBool(revealStr)
+		if err != nil {
+			http.Error(w, "Invalid reveal parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	room, ok := rooms[roomId]
+	if !ok {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if fullReveal && room.settings.RevealDelay == 0 {
+		http.Error(w, "This room does not reveal hands", http.StatusForbidden)
+		return
+	}
+
+	game := &room.game
+	if game.spectatorsFull() {
+		http.Error(w, "Too many spectators", http.StatusForbidden)
+		return
+	}
+	conn := UpgradeWebsocket(w, r, room)
+	if conn == nil {
+		return
+	}
+	id := newPlayerID()
+	game.Network.AddClient(id, conn)
+
+	dto := dtos.ConnectionDTO{
+		PlayerID:   id,
+		Spectator:  true,
+		RoomID:     room.id,
+		MaxPlayers: room.maxPlayers,
+		Players:    room.game.getAllPlayers(),
+		Settings:   room.settings,
+	}
+	game.Network.SendTo(id, dto.Serialize())
+
+	if !game.addSpectator(id, fullReveal) {
+		// Others took the last places while this one connected.
+		game.Network.removeConn(id, conn)
+		conn.Close()
+		return
+	}
+	game.watch(id, conn)
+}
+
+// parseRoomSettings reads the optional settings parameter, a JSON encoded
+// game.RoomSettings. Options that are left out keep their default value.
+func parseRoomSettings(r *http.Request) (game.RoomSettings, error) {
+	settings := game.DefaultRoomSettings()
+	if settingsStr := r.URL.Query().Get("settings"); settingsStr != "" {
+		if err := json.Unmarshal([]byte(settingsStr), &settings); err != nil {
+			return settings, err
+		}
+	}
+	return settings, settings.Validate()
+}
+
+// parseBots reads the optional bots parameter, a comma separated list of the
+// strategies of the bots that fill seats next to the room's creator.
+func parseBots(r *http.Request, maxPlayers int) ([]string, error) {
+	botsStr := r.URL.Query().Get("bots")
+	if botsStr == "" {
+		return nil, nil
+	}
+	strategies := strings.Split(botsStr, ",")
+	if len(strategies) >= maxPlayers {
+		return nil, fmt.Errorf("at most %d bots fit next to the creator", maxPlayers-1)
+	}
+	for i, strategy := range strategies {
+		strategies[i] = strings.TrimSpace(strategy)
+		if _, err := bot.New(strategies[i], nil); err != nil {
+			return nil, err
+		}
+	}
+	return strategies, nil
+}
+
 func AddPlayerToRoom(w *http.ResponseWriter, roomId int, playerName string) *game.Player {
 	r, ok := rooms[roomId]
 	if !ok {
@@ -136,10 +363,20 @@ func AddPlayerToRoom(w *http.ResponseWriter, roomId int, playerName string) *gam
 	}
 
 	player := game.NewPlayer(playerName)
+	player.ID = newPlayerID()
 	g.AddPlayer(player)
 	return player
 }
 
+// newPlayerID returns a random id for a new player.
+func newPlayerID() string {
+	b := make([]byte, 8)
+	if _, err := crand.Read(b); err != nil {
+		log.Printf("Failed to read random player id: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
 func UpgradeWebsocket(w http.ResponseWriter, r *http.Request, room *Room) *websocket.Conn {
 	conn, err := room.game.Network.upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -149,8 +386,28 @@ func UpgradeWebsocket(w http.ResponseWriter, r *http.Request, room *Room) *webso
 	return conn
 }
 
+var roomIDRand = struct {
+	mu sync.Mutex
+	*rand.Rand
+}{
+	Rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+}
+
+// SetRoomIDSource replaces the random source room ids are drawn from.
+func SetRoomIDSource(src rand.Source) {
+	roomIDRand.mu.Lock()
+	defer roomIDRand.mu.Unlock()
+	roomIDRand.Rand = rand.New(src)
+}
+
 // Generate a unique room id
 func generateID() int {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	return ROOM_START_INDEX + r.Intn(ROOM_END_INDEX-ROOM_START_INDEX+1)
+	roomIDRand.mu.Lock()
+	defer roomIDRand.mu.Unlock()
+	for {
+		id := ROOM_START_INDEX + roomIDRand.Intn(ROOM_END_INDEX-ROOM_START_INDEX+1)
+		if _, taken := rooms[id]; !taken {
+			return id
+		}
+	}
 }
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"uno/models/dtos"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCreateRoomRejectsMaxPlayers(t *testing.T) {
+	for _, maxPlayers := range []string{"1", "0", "-2", "two"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/create?player_name=Alice&max_players="+maxPlayers, nil)
+		CreateRoomHandler(w, r)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("max_players=%s: status = %d, want %d", maxPlayers, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSetRoomIDSource(t *testing.T) {
+	SetRoomIDSource(rand.NewSource(7))
+	first := generateID()
+	SetRoomIDSource(rand.NewSource(7))
+	if again := generateID(); again != first {
+		t.Errorf("room id = %d from the same source, want %d", again, first)
+	}
+}
+
+func TestCreateRoomWithBots(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(CreateRoomHandler))
+	t.Cleanup(srv.Close)
+
+	dialer := websocket.Dialer{HandshakeTimeout: 2 * time.Second}
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/create?player_name=Alice&max_players=3&bots=random,heuristic"
+	client, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	var connection dtos.ConnectionDTO
+	readMessage(t, client, "connection", &connection)
+	want := []string{"Alice", "random bot", "heuristic bot"}
+	if !reflect.DeepEqual(connection.Players, want) {
+		t.Errorf("players = %v, want %v", connection.Players, want)
+	}
+	// The room is full, so the game starts and Alice gets a snapshot.
+	for {
+		var message map[string]interface{}
+		if readAnyMessage(t, client, &message) == "sync" {
+			break
+		}
+	}
+}
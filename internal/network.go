@@ -4,27 +4,35 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 	"uno/models/dtos"
 	"uno/models/game"
 
 	"github.com/gorilla/websocket"
 )
 
+const (
+	// sendQueueSize is how many messages a client may fall behind before it
+	// is disconnected.
+	sendQueueSize = 256
+	// writeTimeout is how long a single write to a client may take.
+	writeTimeout = 10 * time.Second
+)
+
 type Network struct {
 	//clients map[*websocket.Conn]*models.Player
-	clients     map[game.Player]*websocket.Conn
+	clients     map[string]*websocket.Conn // keyed by player id
 	upgrader    websocket.Upgrader
 	broadcast   chan string
 	syncChannel chan string
 	gameStarted bool
-	locks       map[game.Player]*sync.Mutex
-	wg          *sync.WaitGroup
-	mu   sync.RWMutex
+	queues      map[string]chan []byte // messages waiting to be written, by client id
+	mu          sync.RWMutex
 }
 
 func NewNetwork() *Network {
 	return &Network{
-		clients: make(map[game.Player]*websocket.Conn),
+		clients: make(map[string]*websocket.Conn),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true // Accepts requests from every source
@@ -32,92 +40,107 @@ func NewNetwork() *Network {
 		},
 		broadcast:   make(chan string),
 		gameStarted: false,
-		locks:       make(map[game.Player]*sync.Mutex),
-		wg:          &sync.WaitGroup{},
+		queues:      make(map[string]chan []byte),
 	}
 }
 
-func (n *Network) AddClient(player game.Player, conn *websocket.Conn) {
+// AddClient registers conn under playerID, replacing any earlier connection,
+// and starts writing the messages sent to it.
+func (n *Network) AddClient(playerID string, conn *websocket.Conn) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	n.clients[player] = conn
-	n.locks[player] = &sync.Mutex{}
+	if queue, ok := n.queues[playerID]; ok {
+		close(queue)
+	}
+	queue := make(chan []byte, sendQueueSize)
+	n.clients[playerID] = conn
+	n.queues[playerID] = queue
+	go writeMessages(playerID, conn, queue)
 }
 
-func (n *Network) RemoveClient(player game.Player) {
+func (n *Network) RemoveClient(playerID string) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	delete(n.clients, player)
+	n.forget(playerID)
+}
+
+// forget drops the client and stops its writer. It must be called with n.mu
+// held.
+func (n *Network) forget(playerID string) {
+	delete(n.clients, playerID)
+	if queue, ok := n.queues[playerID]; ok {
+		close(queue)
+		delete(n.queues, playerID)
+	}
+}
+
+// writeMessages writes the messages queued for a client to its connection,
+// one at a time. Once the queue is closed and every message in it written, it
+// closes the connection. A client that cannot take a message within
+// writeTimeout is disconnected.
+func writeMessages(playerID string, conn *websocket.Conn, queue <-chan []byte) {
+	for message := range queue {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err := conn.WriteMessage(websocket.TextMessage, message)
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				fmt.Printf("Unexpected close error for player %s: %v\n", playerID, err)
+			} else {
+				fmt.Printf("Error writing message to player %s: %v\n", playerID, err)
+			}
+
+			// Closing the connection ends its reader, which removes the
+			// client unless it has connected again since.
+			conn.Close()
+			for range queue {
+			}
+			return
+		}
+	}
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	conn.Close()
 }
 
-func (n *Network) GetClient(player game.Player) (*websocket.Conn, bool) {
+func (n *Network) GetClient(playerID string) (*websocket.Conn, bool) {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
-	conn, ok := n.clients[player]
+	conn, ok := n.clients[playerID]
 	return conn, ok
 }
 
-func (n *Network) GetAllClients() map[game.Player]*websocket.Conn {
+// GetAllClients returns a copy of the clients, which connecting and leaving
+// clients cannot change while it is ranged over.
+func (n *Network) GetAllClients() map[string]*websocket.Conn {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
-	return n.clients
+	clients := make(map[string]*websocket.Conn, len(n.clients))
+	for id, conn := range n.clients {
+		clients[id] = conn
+	}
+	return clients
 }
 
 func (n *Network) BroadcastMessages() {
 	for message := range n.broadcast {
-		clientCount := len(n.clients)
-		clients := n.GetAllClients()
-
-		// Increment the WaitGroup counter for the number of clients
-		if clientCount > 0 {
-			n.wg.Add(clientCount)
-		}
-
 		// Broadcast the message to all players
-		for player, conn := range clients {
-			go func(player game.Player, conn *websocket.Conn, message string) {
-				defer n.wg.Done()
-
-				lock := n.locks[player]
-				lock.Lock()
-				defer lock.Unlock()
-
-				err := conn.WriteMessage(websocket.TextMessage, []byte(message))
-				if err != nil {
-					if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-						fmt.Printf("Unexpected close error for player %s: %v\n", player.Name, err)
-					} else {
-						fmt.Printf("Error writing message to player %s: %v\n", player.Name, err)
-					}
-
-					// Close the connection and remove the player from the map
-					conn.Close()
-
-
-					n.mu.Lock()
-					defer n.mu.Unlock()
-					delete(n.clients, player)
-					delete(n.locks, player)
-				}
-			}(player, conn, message)
+		for playerID := range n.GetAllClients() {
+			n.SendTo(playerID, []byte(message))
 		}
-
-		// Wait for all goroutines to finish broadcasting this message
-		n.wg.Wait()
 	}
 }
 
 func (n *Network) ListenToClient(player *game.Player, r *Room) {
 	game := &r.game
-	go game.Network.BroadcastMessages()
 
-	if (len(game.Network.clients) == r.maxPlayers) && (game.GameStarted == false) {
-		game.Start()
+	if game.startIfFull() {
 		conn_info_dto := dtos.ConnectionDTO{
+			PlayerID:   player.ID,
 			PlayerName: player.Name,
-			RoomID: r.id,
+			RoomID:     r.id,
 			MaxPlayers: r.maxPlayers,
-			Players: r.game.getAllPlayers(),
+			Players:    r.game.getAllPlayers(),
+			Settings:   r.settings,
 		}
 		go game.SyncAllPlayers()
 
@@ -126,43 +149,68 @@ func (n *Network) ListenToClient(player *game.Player, r *Room) {
 	} else {
 		game.Network.BroadcastInfoMessage("Waiting for players to join the game.")
 	}
-	conn := n.clients[*player]
+	conn, _ := n.GetClient(player.ID)
+	n.ReadCommands(player, game, conn)
+}
+
+// ReadCommands hands every message read from conn to the game until the
+// connection drops. A connection replaced by a reconnect just ends quietly.
+func (n *Network) ReadCommands(player *game.Player, g *Game, conn *websocket.Conn) {
 	for {
 		_, msg, err := conn.ReadMessage()
 		if err != nil {
+			if n.removeConn(player.ID, conn) {
+				g.playerLeft(player)
+			}
 			return
 		}
 
-		go game.HandleCommand(msg, player)
+		g.HandleCommand(msg, player)
 
 	}
 }
 
+// removeConn forgets conn, unless the player has connected again since.
+func (n *Network) removeConn(playerID string, conn *websocket.Conn) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.clients[playerID] != conn {
+		return false
+	}
+	n.forget(playerID)
+	return true
+}
+
 func (n *Network) BroadcastMessage(message []byte) {
 	n.broadcast <- string(message)
 }
 
 func (n *Network) SendMessage(p *game.Player, message []byte) error {
-	conn, exists := n.clients[*p]
-	if !exists {
-		return fmt.Errorf("player %s not found in network clients", p.Name)
+	if err := n.SendTo(p.ID, message); err != nil {
+		return fmt.Errorf("player %s: %v", p.Name, err)
 	}
+	return nil
+}
 
-	lock, exists := n.locks[*p]
+// SendTo queues message for the client with the given id, a player or a
+// spectator. It never waits for the client: one that has fallen too far
+// behind is disconnected instead.
+func (n *Network) SendTo(id string, message []byte) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	queue, exists := n.queues[id]
 	if !exists {
-		return fmt.Errorf("no mutex found for player %s", p.Name)
+		return fmt.Errorf("client %s not found in network clients", id)
 	}
 
-	// Lock the mutex for the player's connection
-	lock.Lock()
-	defer lock.Unlock()
-
-	// Perform the write operation
-	err := conn.WriteMessage(websocket.TextMessage, message)
-	if err != nil {
-		return fmt.Errorf("error sending message to player %s: %v", p.Name, err)
+	select {
+	case queue <- message:
+		return nil
+	default:
+		// Closing the connection ends its reader, which removes the client.
+		n.clients[id].Close()
+		return fmt.Errorf("client %s stopped reading, disconnected it", id)
 	}
-	return nil
 }
 
 func (n *Network) SendInfoMessage(p *game.Player, message string) {
@@ -179,7 +227,10 @@ func (n *Network) BroadcastConnectionInfo() {
 
 }
 
+// CloseConnection drops p and closes their connection once the messages
+// already sent to them have been written.
 func (n *Network) CloseConnection(p *game.Player) {
-	conn := n.clients[*p]
-	conn.Close()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.forget(p.ID)
 }
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"uno/models/game"
+)
+
+func TestGetAllClientsIsACopy(t *testing.T) {
+	g := NewGame(game.DefaultRoomSettings().Rules, 1)
+	connectClient(t, g, "alice")
+	connectClient(t, g, "bob")
+	clients := g.Network.GetAllClients()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Network.RemoveClient("bob")
+	}()
+	for range clients {
+	}
+	<-done
+
+	if len(clients) != 2 {
+		t.Errorf("clients = %v, want alice and bob as they were when they were read", clients)
+	}
+}
+
+func TestSendToDropsClientThatStopsReading(t *testing.T) {
+	g := NewGame(game.DefaultRoomSettings().Rules, 1)
+	client := connectClient(t, g, "alice")
+
+	// The client never reads, so the socket buffers fill up and then the
+	// queue; sending must not block on the way.
+	message := []byte(strings.Repeat("x", 1<<16))
+	var err error
+	for i := 0; i < 100000 && err == nil; i++ {
+		err = g.Network.SendTo("alice", message)
+	}
+	if err == nil {
+		t.Fatalf("SendTo kept queueing for a client that does not read")
+	}
+
+	client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err := client.ReadMessage(); err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				t.Fatalf("the connection of the slow client is still open")
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"uno/internal/engine"
+	"uno/models/dtos"
+	"uno/models/game"
+)
+
+// sendPatches tells every player how the events moved the state from version
+// from to the current one. Events a patch cannot describe, like hands changing
+// owner or a new round being dealt, get everybody a full snapshot instead.
+// It must be called with g.mu held.
+func (g *Game) sendPatches(from int, events []engine.Event) {
+	snapshot := false
+	for _, event := range events {
+		switch event.(type) {
+		case engine.HandsSwapped, engine.HandsRotated, engine.RoundStarted:
+			snapshot = true
+		}
+	}
+
+	for _, p := range g.Players {
+		var message []byte
+		if snapshot {
+			message = g.syncMessage(p)
+		} else {
+			patch := g.patchFor(p, events)
+			patch.From = from
+			message = patch.Serialize()
+		}
+		if message != nil {
+			g.Network.SendMessage(p, message)
+		}
+	}
+	g.sendSpectatorPatches(from, events, snapshot)
+}
+
+// patchFor builds the patch of events as seen by p, or by a spectator when p
+// is nil.
+func (g *Game) patchFor(p *game.Player, events []engine.Event) dtos.PatchDTO {
+	patch := dtos.PatchDTO{
+		Version:     g.Version,
+		Phase:       g.Phase.String(),
+		PendingDraw: g.PendingDraw,
+		Reverse:     g.GameDirection,
+		DrawPile:    g.GameDeck.NumberOfCards(),
+		DiscardPile: g.DisposedGameDeck.NumberOfCards(),
+		Events:      []dtos.PatchEvent{},
+		Deadline:    g.deadlineMillis(),
+		TimeBanks:   g.timeBanks(),
+	}
+	if p != nil {
+		patch.Moves = g.legalMoves(p)
+	}
+	for _, event := range events {
+		if e, ok := patchEvent(p, event); ok {
+			patch.Events = append(patch.Events, e)
+		}
+	}
+	return patch
+}
+
+// patchEvent describes a single event as seen by p, which may be nil for a
+// public view. Events that do not change what clients see are skipped.
+func patchEvent(p *game.Player, event engine.Event) (dtos.PatchEvent, bool) {
+	switch e := event.(type) {
+	case engine.CardPlayed:
+		card := e.Card
+		return dtos.PatchEvent{Kind: dtos.PatchCardPlayed, Player: e.Player.ID, Card: &card, Color: e.Color}, true
+	case engine.ColorChosen:
+		return dtos.PatchEvent{Kind: dtos.PatchColorChosen, Player: e.Player.ID, Color: e.Color}, true
+	case engine.CardsDrawn:
+		drawn := dtos.PatchEvent{Kind: dtos.PatchCardsDrawn, Player: e.Player.ID, Count: len(e.Cards)}
+		if p != nil && e.Player == p {
+			drawn.Cards = e.Cards
+		}
+		return drawn, true
+	case engine.TurnChanged:
+		return dtos.PatchEvent{Kind: dtos.PatchTurnChanged, Player: e.Player.ID}, true
+	case engine.UnoCalled:
+		return dtos.PatchEvent{Kind: dtos.PatchUnoCalled, Player: e.Player.ID}, true
+	}
+	return dtos.PatchEvent{}, false
+}
+
+// recordLastAction keeps the last public action among events for the table
+// view. Turn changes follow every action and are not recorded.
+func (g *Game) recordLastAction(events []engine.Event) {
+	for _, event := range events {
+		if e, ok := patchEvent(nil, event); ok && e.Kind != dtos.PatchTurnChanged {
+			g.lastAction = &e
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"uno/internal/engine"
+	"uno/models/dtos"
+	"uno/models/game"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSendPatches(t *testing.T) {
+	g, _ := newTimedGame(t, game.DefaultRoomSettings())
+	drawer := g.ActivePlayer
+	clients := map[*game.Player]*websocket.Conn{}
+	for _, p := range g.Players {
+		clients[p] = connectClient(t, g, p.ID)
+	}
+
+	from := g.version()
+	to, err := g.apply(drawer, engine.DrawCard{})
+	if err != nil {
+		t.Fatalf("DrawCard: %v", err)
+	}
+
+	for p, client := range clients {
+		var patch dtos.PatchDTO
+		readUntil(t, client, "patch", &patch)
+		if patch.From != from || patch.Version != to {
+			t.Errorf("%s: patch from %d to %d, want from %d to %d", p.Name, patch.From, patch.Version, from, to)
+		}
+		var drawn *dtos.PatchEvent
+		for i, e := range patch.Events {
+			if e.Kind == dtos.PatchCardsDrawn {
+				drawn = &patch.Events[i]
+			}
+		}
+		if drawn == nil || drawn.Count != 1 {
+			t.Fatalf("%s: events = %+v, want one card drawn", p.Name, patch.Events)
+		}
+		if drawn.Player != drawer.ID {
+			t.Errorf("%s: the drawer is %q, want their id %q", p.Name, drawn.Player, drawer.ID)
+		}
+		if p == drawer && len(drawn.Cards) != 1 {
+			t.Errorf("the drawer sees %d drawn cards, want 1", len(drawn.Cards))
+		}
+		if p != drawer && len(drawn.Cards) != 0 {
+			t.Errorf("%s sees the drawn cards %v", p.Name, drawn.Cards)
+		}
+	}
+}
+
+func TestSyncAtCurrentVersion(t *testing.T) {
+	g, _ := newTimedGame(t, game.DefaultRoomSettings())
+	p := g.Players[0]
+	client := connectClient(t, g, p.ID)
+
+	sync := func(seq int, id string, version int) {
+		g.HandleCommand([]byte(fmt.Sprintf(`{"id": "%s", "seq": %d, "type": "SYNC_GAME_STATE", "obj": {"version": %d}}`, id, seq, version)), p)
+	}
+	// A stale version gets a snapshot before the ack.
+	sync(1, "stale", g.version()-1)
+	var snapshot dtos.SyncDTO
+	readUntil(t, client, "sync", &snapshot)
+	var ack dtos.AckDTO
+	readUntil(t, client, "ack", &ack)
+
+	sync(2, "current", g.version())
+	for {
+		var obj json.RawMessage
+		switch readAnyMessage(t, client, &obj) {
+		case "sync":
+			t.Fatalf("got a snapshot for the version the client holds")
+		case "ack":
+			json.Unmarshal(obj, &ack)
+			if !ack.Accepted || ack.CorrelationID != "current" {
+				t.Errorf("ack = %+v, want the sync accepted", ack)
+			}
+			return
+		}
+	}
+}
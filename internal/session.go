@@ -0,0 +1,121 @@
+package internal
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"uno/internal/engine"
+	"uno/models/game"
+)
+
+// errPaused rejects moves while the game waits for a player to reconnect.
+var errPaused = errors.New("the game is paused until every player is back")
+
+// session ties a secret token to the seat it can reclaim.
+type session struct {
+	roomID   int
+	playerID string
+}
+
+var sessions = struct {
+	mu      sync.Mutex
+	entries map[string]session
+}{
+	entries: make(map[string]session),
+}
+
+// newSession returns the token that lets the player reclaim their seat.
+func newSession(roomID int, playerID string) string {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		log.Printf("Failed to read random session token: %v", err)
+	}
+	token := hex.EncodeToString(b)
+
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	sessions.entries[token] = session{roomID: roomID, playerID: playerID}
+	return token
+}
+
+func findSession(token string) (session, bool) {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	s, ok := sessions.entries[token]
+	return s, ok
+}
+
+// playerLeft is called once p's connection is gone. The game is paused for
+// the room's reconnect grace period; after that it goes on without p.
+func (g *Game) playerLeft(p *game.Player) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Network.BroadcastInfoMessage(fmt.Sprintf("%s disconnected", p.Name))
+	if !g.GameStarted || g.Phase == engine.PhaseGameOver {
+		return
+	}
+	grace := time.Duration(g.Room.settings.ReconnectGrace) * time.Second
+	if grace == 0 {
+		g.away[p.ID] = nil
+		g.autoPlay()
+		return
+	}
+	back := make(chan struct{})
+	g.away[p.ID] = back
+	expired := g.clock.After(grace)
+	go func() {
+		select {
+		case <-expired:
+			g.graceExpired(p, back)
+		case <-back:
+		}
+	}()
+	g.updateTurnClock(false)
+	g.Network.BroadcastInfoMessage(fmt.Sprintf("Game paused. Waiting %s for %s to reconnect.", grace, p.Name))
+}
+
+// graceExpired lets the game go on without p, unless p came back since the
+// grace period that back belongs to was started.
+func (g *Game) graceExpired(p *game.Player, back chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.away[p.ID] != back {
+		return
+	}
+	g.away[p.ID] = nil
+	g.Network.BroadcastInfoMessage(fmt.Sprintf("%s did not come back. The game goes on without them.", p.Name))
+	g.autoPlay()
+}
+
+// playerReturned gives p their seat back. Their command seq starts over.
+func (g *Game) playerReturned(p *game.Player) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.seqs, p.ID)
+	delete(g.timeouts, p.ID)
+	if back := g.away[p.ID]; back != nil {
+		close(back)
+	}
+	delete(g.away, p.ID)
+	g.Network.BroadcastInfoMessage(fmt.Sprintf("%s reconnected", p.Name))
+	// The game may have been waiting for p while another seat is played for.
+	g.autoPlay()
+}
+
+// paused reports whether the game waits for a player to reconnect. It must
+// be called with g.mu held.
+func (g *Game) paused() bool {
+	for _, back := range g.away {
+		if back != nil {
+			return true
+		}
+	}
+	return false
+}
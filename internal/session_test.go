@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uno/internal/engine"
+	"uno/models/dtos"
+	"uno/models/game"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestReconnectHandlerRejectsToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"missing", "", http.StatusBadRequest},
+		{"unknown", "?token=0123456789abcdef", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ReconnectHandler(w, httptest.NewRequest(http.MethodGet, "/reconnect"+tt.query, nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconnectReplacesSocket(t *testing.T) {
+	g, _ := newTimedGame(t, game.DefaultRoomSettings())
+	old := connectClient(t, g, "p1")
+	token := newSession(g.Room.id, "p1")
+
+	srv := httptest.NewServer(http.HandlerFunc(ReconnectHandler))
+	t.Cleanup(srv.Close)
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/reconnect?token="+token, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	var conn dtos.ConnectionDTO
+	readMessage(t, client, "connection", &conn)
+	if conn.PlayerID != "p1" || conn.SessionToken != token {
+		t.Errorf("connection = %+v, want the seat of p1 and the same token", conn)
+	}
+	// The news of the return is broadcast, and may come before the sync.
+	var sync dtos.SyncDTO
+	for readAnyMessage(t, client, &sync) != "sync" {
+	}
+
+	old.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := old.ReadMessage(); err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				t.Fatalf("the replaced socket is still open")
+			}
+			break
+		}
+	}
+}
+
+func TestPauseWhileAway(t *testing.T) {
+	settings := game.DefaultRoomSettings()
+	settings.ReconnectGrace = 30
+	settings.InactivityTimeout = 0
+	g, clock := newTimedGame(t, settings)
+
+	g.mu.Lock()
+	active := g.ActivePlayer
+	other := g.Players[0]
+	if other == active {
+		other = g.Players[1]
+	}
+	g.mu.Unlock()
+
+	g.playerLeft(other)
+	if _, err := g.apply(active, engine.DrawCard{}); err != errPaused {
+		t.Fatalf("move while %s is away: err = %v, want %v", other.Name, err, errPaused)
+	}
+
+	clock.Advance(29 * time.Second)
+	g.mu.Lock()
+	paused := g.paused()
+	g.mu.Unlock()
+	if !paused {
+		t.Fatalf("the game went on before the grace period ran out")
+	}
+	clock.Advance(time.Second)
+	waitFor(t, g, "the grace period to run out", func() bool { return !g.paused() })
+	if _, err := g.apply(active, engine.DrawCard{}); err != nil {
+		t.Errorf("move after the grace period: %v", err)
+	}
+}
+
+func TestPlayerReturnedEndsPause(t *testing.T) {
+	settings := game.DefaultRoomSettings()
+	settings.ReconnectGrace = 30
+	g, _ := newTimedGame(t, settings)
+	p := g.Players[1]
+
+	g.playerLeft(p)
+	g.playerReturned(p)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused() {
+		t.Errorf("the game is still paused after %s came back", p.Name)
+	}
+	if _, away := g.away[p.ID]; away {
+		t.Errorf("%s is still away", p.Name)
+	}
+}
@@ -0,0 +1,224 @@
+package internal
+
+import (
+	"errors"
+	"log"
+	"time"
+	"uno/internal/engine"
+	"uno/models/commands"
+	"uno/models/constants/errcode"
+	"uno/models/dtos"
+	"uno/models/game"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// maxSpectators caps the spectators of a room.
+	maxSpectators = 64
+	// maxPendingReveals caps the full reveals a room holds back at once. The
+	// oldest are dropped first; every later one shows all hands again anyway.
+	maxPendingReveals = 1024
+)
+
+// reveal is a full reveal held back until the reveal delay has passed.
+type reveal struct {
+	at      time.Time
+	version int
+	message []byte
+}
+
+// addSpectator starts sending the public view of the game to the client with
+// the given id. With fullReveal the client also gets every hand once the
+// room's reveal delay has passed. It fails when the room has no room for
+// another spectator.
+func (g *Game) addSpectator(id string, fullReveal bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.spectators) >= maxSpectators {
+		return false
+	}
+	g.spectators[id] = fullReveal
+	if message := g.spectatorMessage(); message != nil {
+		g.Network.SendTo(id, message)
+	}
+	return true
+}
+
+// spectatorsFull reports whether the room has as many spectators as it takes.
+func (g *Game) spectatorsFull() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.spectators) >= maxSpectators
+}
+
+func (g *Game) removeSpectator(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.spectators, id)
+}
+
+// spectatorMessage serializes the public view of the game, or returns nil
+// until the game has started. It must be called with g.mu held.
+func (g *Game) spectatorMessage() []byte {
+	state, ok := g.gameState()
+	if !ok {
+		return nil
+	}
+	dto := dtos.SpectateDTO{Game: state, Room: g.roomState()}
+	return dto.Serialize()
+}
+
+// syncSpectators sends every spectator the public view of the game.
+func (g *Game) syncSpectators() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	message := g.spectatorMessage()
+	if message == nil {
+		return
+	}
+	for id := range g.spectators {
+		g.Network.SendTo(id, message)
+	}
+}
+
+// sendSpectatorPatches tells every spectator how the events moved the state
+// from version from, or sends them the public view when a patch cannot
+// describe the events. It must be called with g.mu held.
+func (g *Game) sendSpectatorPatches(from int, events []engine.Event, snapshot bool) {
+	if len(g.spectators) == 0 {
+		return
+	}
+	var message []byte
+	if snapshot {
+		message = g.spectatorMessage()
+	} else {
+		patch := g.patchFor(nil, events)
+		patch.From = from
+		message = patch.Serialize()
+	}
+	if message == nil {
+		return
+	}
+	for id := range g.spectators {
+		g.Network.SendTo(id, message)
+	}
+}
+
+// queueReveal holds back a full reveal of the current hands until the room's
+// reveal delay has passed. Hands are only serialized while a spectator asked
+// for the reveal. It must be called with g.mu held.
+func (g *Game) queueReveal() {
+	delay := time.Duration(g.Room.settings.RevealDelay) * time.Second
+	if delay == 0 {
+		return
+	}
+	if g.revealWanted() {
+		dto := dtos.RevealDTO{Version: g.Version, Hands: make(map[string][]game.Card, len(g.Players))}
+		for _, p := range g.Players {
+			dto.Hands[p.ID] = append([]game.Card{}, p.Deck.Cards...)
+		}
+		if len(g.reveals) >= maxPendingReveals {
+			log.Printf("Too many pending reveals in room %d, dropped the one of version %d", g.Room.id, g.reveals[0].version)
+			g.reveals = g.reveals[1:]
+		}
+		g.reveals = append(g.reveals, reveal{at: g.clock.Now().Add(delay), version: g.Version, message: dto.Serialize()})
+	}
+	// runReveals also wakes up to stop at the end of the game.
+	select {
+	case g.wakeReveals <- struct{}{}:
+	default:
+	}
+}
+
+// revealWanted reports whether any spectator asked for the full reveal. It
+// must be called with g.mu held.
+func (g *Game) revealWanted() bool {
+	for _, fullReveal := range g.spectators {
+		if fullReveal {
+			return true
+		}
+	}
+	return false
+}
+
+// runReveals sends the full reveals to the spectators who asked for them, in
+// order, each once its delay has passed. It stops once the game is over and
+// every reveal has been sent.
+func (g *Game) runReveals() {
+	for {
+		g.mu.Lock()
+		if len(g.reveals) == 0 {
+			over := g.Phase == engine.PhaseGameOver
+			g.mu.Unlock()
+			if over {
+				return
+			}
+			<-g.wakeReveals
+			continue
+		}
+		r := g.reveals[0]
+		g.reveals = g.reveals[1:]
+		g.mu.Unlock()
+
+		if wait := r.at.Sub(g.clock.Now()); wait > 0 {
+			<-g.clock.After(wait)
+		}
+		g.mu.Lock()
+		for id, fullReveal := range g.spectators {
+			if fullReveal {
+				g.Network.SendTo(id, r.message)
+			}
+		}
+		g.mu.Unlock()
+	}
+}
+
+// watch answers the commands of a spectator until their connection drops.
+// Spectators can only ask for a sync; anything else is rejected.
+func (g *Game) watch(id string, conn *websocket.Conn) {
+	defer g.removeSpectator(id)
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			g.Network.removeConn(id, conn)
+			return
+		}
+		g.handleSpectatorCommand(id, msg)
+	}
+}
+
+func (g *Game) handleSpectatorCommand(id string, data []byte) {
+	base, cmd, err := commands.DeserializeCommand(data)
+	if err != nil {
+		code := errcode.MALFORMED
+		if errors.Is(err, commands.ErrUnknownCommand) {
+			code = errcode.UNKNOWN_COMMAND
+		}
+		g.rejectSpectator(id, base, code, err.Error())
+		return
+	}
+	c, ok := cmd.(*commands.SyncCommand)
+	if !ok {
+		g.rejectSpectator(id, base, errcode.SPECTATOR, "spectators cannot make moves")
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c.Version != g.Version {
+		if message := g.spectatorMessage(); message != nil {
+			g.Network.SendTo(id, message)
+		}
+	}
+	ack := dtos.AckDTO{Seq: base.Seq, CorrelationID: base.ID, Accepted: true, Version: g.Version}
+	g.Network.SendTo(id, ack.Serialize())
+}
+
+// rejectSpectator answers cmd with an ErrorDTO followed by a negative ack.
+func (g *Game) rejectSpectator(id string, cmd commands.BaseCommand, code errcode.Code, message string) {
+	dto := dtos.ErrorDTO{Code: code, Message: message, CorrelationID: cmd.ID}
+	g.Network.SendTo(id, dto.Serialize())
+	ack := dtos.AckDTO{Seq: cmd.Seq, CorrelationID: cmd.ID, Accepted: false, Version: g.version()}
+	g.Network.SendTo(id, ack.Serialize())
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"uno/internal/engine"
+	"uno/models/game"
+)
+
+func TestSpectatorsSeeNoHands(t *testing.T) {
+	g, _ := newTimedGame(t, game.DefaultRoomSettings())
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	p := g.ActivePlayer
+	drawn := engine.CardsDrawn{Player: p, Cards: append([]game.Card{}, p.Deck.Cards[:2]...)}
+	patch := g.patchFor(nil, []engine.Event{drawn})
+	if got := patch.Events[0].Count; got != 2 {
+		t.Errorf("spectator patch counts %d drawn cards, want 2", got)
+	}
+
+	messages := map[string][]byte{
+		"view":  g.spectatorMessage(),
+		"patch": patch.Serialize(),
+	}
+	for name, message := range messages {
+		if message == nil {
+			t.Fatalf("no spectator %s", name)
+		}
+		if s := string(message); strings.Contains(s, `"Cards"`) || strings.Contains(s, `"cards":[`) {
+			t.Errorf("spectator %s shows cards: %s", name, s)
+		}
+	}
+}
+
+func TestSpectatorCap(t *testing.T) {
+	room := NewRoom(2, game.DefaultRoomSettings(), 1)
+	t.Cleanup(func() { delete(rooms, room.id) })
+	g := &room.game
+
+	for i := 0; i < maxSpectators; i++ {
+		if !g.addSpectator(fmt.Sprintf("spectator %d", i), false) {
+			t.Fatalf("spectator %d was turned away", i+1)
+		}
+	}
+	if !g.spectatorsFull() {
+		t.Errorf("spectatorsFull() = false with %d spectators", maxSpectators)
+	}
+	if g.addSpectator("one too many", false) {
+		t.Errorf("spectator %d was let in", maxSpectators+1)
+	}
+	g.removeSpectator("spectator 0")
+	if !g.addSpectator("latecomer", false) {
+		t.Errorf("spectator was turned away after another left")
+	}
+}
+
+func TestQueueReveal(t *testing.T) {
+	settings := game.DefaultRoomSettings()
+	settings.RevealDelay = 5
+	room := NewRoom(2, settings, 1)
+	t.Cleanup(func() { delete(rooms, room.id) })
+	g := &room.game
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	g.clock = clock
+
+	g.mu.Lock()
+	g.queueReveal()
+	if len(g.reveals) != 0 {
+		t.Errorf("queued %d reveals that nobody asked for", len(g.reveals))
+	}
+	g.spectators["watcher"] = true
+	for i := 0; i <= maxPendingReveals; i++ {
+		g.queueReveal()
+	}
+	if got := len(g.reveals); got != maxPendingReveals {
+		t.Errorf("queued %d reveals, want at most %d", got, maxPendingReveals)
+	}
+	g.Phase = engine.PhaseGameOver
+	g.queueReveal()
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.runReveals()
+		close(done)
+	}()
+	waitFor(t, g, "the first reveal to wait", func() bool { return clock.timers() > 0 })
+	clock.Advance(5 * time.Second)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("runReveals did not stop after the end of the game")
+	}
+}
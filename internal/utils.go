@@ -4,12 +4,6 @@ import (
 	"uno/models/game"
 )
 
-func convertDirectionToInteger(direction bool) int {
-	if direction {
-		return 1
-	}
-	return -1
-}
 func removeCardFromHand(hand []game.Card, card game.Card) []game.Card {
 	for i, c := range hand {
 		if c == card {
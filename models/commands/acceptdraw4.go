@@ -0,0 +1,4 @@
+package commands
+
+type AcceptDraw4Command struct {
+}
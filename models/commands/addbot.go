@@ -0,0 +1,7 @@
+package commands
+
+// AddBotCommand lets the host fill an empty seat with a bot played by the
+// named strategy.
+type AddBotCommand struct {
+	Strategy string `json:"strategy"`
+}
@@ -2,32 +2,46 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
+// ErrUnknownCommand is returned for a command type that is not registered.
+var ErrUnknownCommand = errors.New("unknown command type")
+
+// BaseCommand is the envelope of every command. ID is an optional
+// correlation id chosen by the client and echoed back in errors. Seq numbers
+// the player's commands; each one has to be higher than the last.
 type BaseCommand struct {
+	ID     string          `json:"id,omitempty"`
+	Seq    int             `json:"seq"`
 	Type   string          `json:"type"`
 	Object json.RawMessage `json:"obj"`
 }
 
-func DeserializeCommand(data []byte) (interface{}, error) {
+// DeserializeCommand decodes a command. The returned BaseCommand is filled in
+// as far as decoding got, so a rejected command can still be correlated.
+func DeserializeCommand(data []byte) (BaseCommand, interface{}, error) {
 	// Unmarshal the base command to determine the type
 	var baseCmd BaseCommand
 	if err := json.Unmarshal(data, &baseCmd); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal command object: %w", err)
+		return baseCmd, nil, fmt.Errorf("failed to unmarshal command object: %w", err)
 	}
 
 	// Get the factory for the command type
 	factory, err := GetCommandFactory(baseCmd.Type)
 	if err != nil {
-		return nil, err
+		return baseCmd, nil, err
 	}
 
 	// Use the factory to create a new instance of the command
 	cmdInstance := factory()
+	if len(baseCmd.Object) == 0 {
+		return baseCmd, cmdInstance, nil
+	}
 	if err := json.Unmarshal(baseCmd.Object, cmdInstance); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal object for command type %s: %w", baseCmd.Type, err)
+		return baseCmd, nil, fmt.Errorf("failed to unmarshal object for command type %s: %w", baseCmd.Type, err)
 	}
 
-	return cmdInstance, nil
+	return baseCmd, cmdInstance, nil
 }
@@ -0,0 +1,4 @@
+package commands
+
+type CallUnoCommand struct {
+}
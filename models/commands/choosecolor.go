@@ -0,0 +1,5 @@
+package commands
+
+type ChooseColorCommand struct {
+	Color string `json:"color"`
+}
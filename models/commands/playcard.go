@@ -1,6 +1,7 @@
 package commands
 
 type PlayCardCommand struct {
-	CardIndex int `json:"card_index"`
-	NewColor  string `json:"new_color"`
+	CardID   int    `json:"card_id"`
+	NewColor string `json:"new_color"`
+	CallUno  bool   `json:"call_uno"`
 }
@@ -28,7 +28,7 @@ func GetCommandFactory(commandType string) (CommandFactory, error) {
 	defer CommandRegistry.mu.RUnlock()
 	factory, exists := CommandRegistry.entries[commandType]
 	if !exists {
-		return nil, fmt.Errorf("no factory registered for command type: %s", commandType)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCommand, commandType)
 	}
 	return factory, nil
 }
@@ -37,5 +37,13 @@ func init() {
 	RegisterCommand("SYNC_GAME_STATE", func() interface{} { return &SyncCommand{} })
 	RegisterCommand("PLAY_CARD", func() interface{} { return &PlayCardCommand{} })
 	RegisterCommand("DRAW_CARD", func() interface{} { return &DrawCardComamnd{} })
+	RegisterCommand("PASS", func() interface{} { return &PassCommand{} })
+	RegisterCommand("CHOOSE_COLOR", func() interface{} { return &ChooseColorCommand{} })
+	RegisterCommand("CHALLENGE_DRAW_4", func() interface{} { return &ChallengeDraw4Command{} })
+	RegisterCommand("ACCEPT_DRAW_4", func() interface{} { return &AcceptDraw4Command{} })
+	RegisterCommand("CALL_UNO", func() interface{} { return &CallUnoCommand{} })
+	RegisterCommand("CATCH_UNO", func() interface{} { return &CatchUnoCommand{} })
+	RegisterCommand("SWAP_HANDS", func() interface{} { return &SwapHandsCommand{} })
+	RegisterCommand("ADD_BOT", func() interface{} { return &AddBotCommand{} })
 
 }
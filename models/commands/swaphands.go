@@ -0,0 +1,6 @@
+package commands
+
+// SwapHandsCommand names the opponent to swap hands with by player id.
+type SwapHandsCommand struct {
+	Target string `json:"target"`
+}
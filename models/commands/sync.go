@@ -1,4 +1,7 @@
 package commands
 
+// SyncCommand asks for a full snapshot. Version is the state version the
+// client holds; when it is current there is nothing to send.
 type SyncCommand struct {
+	Version int `json:"version"`
 }
@@ -0,0 +1,37 @@
+package errcode
+
+// Code tells a client why one of its commands was rejected.
+type Code string
+
+const (
+	// Command errors, reported before the command reaches the game.
+	MALFORMED       Code = "MALFORMED"
+	UNKNOWN_COMMAND Code = "UNKNOWN_COMMAND"
+	OUT_OF_ORDER    Code = "OUT_OF_ORDER"
+
+	// Move errors, reported by the rules engine.
+	NOT_YOUR_TURN    Code = "NOT_YOUR_TURN"
+	ILLEGAL_CARD     Code = "ILLEGAL_CARD"
+	UNKNOWN_CARD     Code = "UNKNOWN_CARD"
+	CARD_NOT_OWNED   Code = "CARD_NOT_OWNED"
+	BAD_COLOR        Code = "BAD_COLOR"
+	BAD_TARGET       Code = "BAD_TARGET"
+	ILLEGAL_MOVE     Code = "ILLEGAL_MOVE"
+	JUMP_IN_TOO_LATE Code = "JUMP_IN_TOO_LATE"
+	GAME_OVER        Code = "GAME_OVER"
+
+	// PAUSED is reported while the game waits for a player to reconnect.
+	PAUSED Code = "PAUSED"
+
+	// Room errors.
+	NOT_HOST         Code = "NOT_HOST"
+	ROOM_FULL        Code = "ROOM_FULL"
+	GAME_STARTED     Code = "GAME_STARTED"
+	UNKNOWN_STRATEGY Code = "UNKNOWN_STRATEGY"
+
+	// SPECTATOR is reported when a spectator sends anything but SYNC.
+	SPECTATOR Code = "SPECTATOR"
+
+	// INTERNAL is reported when the server failed to handle a valid command.
+	INTERNAL Code = "INTERNAL"
+)
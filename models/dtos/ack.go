@@ -0,0 +1,16 @@
+package dtos
+
+// AckDTO answers every command with whether it was accepted and the version
+// of the game state once it was handled. A rejected command is also answered
+// with an ErrorDTO that explains why.
+type AckDTO struct {
+	Seq           int    `json:"seq"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	Accepted      bool   `json:"accepted"`
+	Version       int    `json:"version"`
+}
+
+func (dto AckDTO) Serialize() []byte {
+	return Serialize(
+		dto, "ack")
+}
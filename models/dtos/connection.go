@@ -1,10 +1,16 @@
 package dtos
 
+import "uno/models/game"
+
 type ConnectionDTO struct {
-	PlayerName string   `json:"player_name"`
-	RoomID     int      `json:"room_id"`
-	MaxPlayers int      `json:"max_players"`
-	Players    []string `json:"players"`
+	PlayerID     string            `json:"player_id"`
+	PlayerName   string            `json:"player_name"`
+	SessionToken string            `json:"session_token,omitempty"` // secret, reclaims the seat through /reconnect
+	Spectator    bool              `json:"spectator,omitempty"`     // PlayerID is a spectator id, there is no seat
+	RoomID       int               `json:"room_id"`
+	MaxPlayers   int               `json:"max_players"`
+	Players      []string          `json:"players"`
+	Settings     game.RoomSettings `json:"settings"`
 }
 
 func (dto ConnectionDTO) Serialize() []byte {
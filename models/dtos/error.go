@@ -0,0 +1,16 @@
+package dtos
+
+import "uno/models/constants/errcode"
+
+// ErrorDTO rejects a command. CorrelationID echoes the id the client sent
+// with the command so it can tell which one failed.
+type ErrorDTO struct {
+	Code          errcode.Code `json:"code"`
+	Message       string       `json:"message"`
+	CorrelationID string       `json:"correlation_id,omitempty"`
+}
+
+func (dto ErrorDTO) Serialize() []byte {
+	return Serialize(
+		dto, "error")
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import "uno/models/game"
+
+type JumpInDTO struct {
+	PlayerID string    `json:"player_id"`
+	Card     game.Card `json:"card"`
+}
+
+func (dto JumpInDTO) Serialize() []byte {
+	return Serialize(
+		dto, "jump_in")
+}
@@ -0,0 +1,14 @@
+package dtos
+
+// LegalMovesDTO tells a player what they may do right now. It is only ever
+// sent to the player it belongs to.
+type LegalMovesDTO struct {
+	PlayableCards []int `json:"playable_cards"`
+	Draw          bool  `json:"draw"`
+	Pass          bool  `json:"pass"`
+	ChooseColor   bool  `json:"choose_color"`
+	Challenge     bool  `json:"challenge"`
+	SwapHands     bool  `json:"swap_hands"`
+	CallUno       bool  `json:"call_uno"`
+	CatchUno      bool  `json:"catch_uno"`
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"uno/models/constants/color"
+	"uno/models/game"
+)
+
+// Kinds of PatchEvent.
+const (
+	PatchCardPlayed  = "card_played"
+	PatchCardsDrawn  = "cards_drawn"
+	PatchTurnChanged = "turn_changed"
+	PatchColorChosen = "color_chosen"
+	PatchUnoCalled   = "uno_called"
+)
+
+// PatchDTO moves a client from state version From to Version. A client that
+// is not at From has missed a patch and has to ask for a full sync. The other
+// fields are the values after the patch.
+type PatchDTO struct {
+	From        int           `json:"from"`
+	Version     int           `json:"version"`
+	Phase       string        `json:"phase"`
+	PendingDraw int           `json:"pending_draw"`
+	Reverse     bool          `json:"reverse"`
+	DrawPile    int           `json:"draw_pile"`
+	DiscardPile int           `json:"discard_pile"`
+	Events      []PatchEvent  `json:"events"`
+	Moves       LegalMovesDTO `json:"moves"`
+
+	// Deadline and TimeBanks are the same as in GameState.
+	Deadline  int64            `json:"deadline,omitempty"`
+	TimeBanks map[string]int64 `json:"time_banks,omitempty"`
+}
+
+// PatchEvent is a single change to the game state. Only the fields of its
+// Kind are set. Cards is only sent to the player who drew them; everybody
+// else gets Count. Drawing a card also takes back the player's UNO call.
+type PatchEvent struct {
+	Kind   string      `json:"kind"`
+	Player string      `json:"player,omitempty"` // player id
+	Card   *game.Card  `json:"card,omitempty"`
+	Color  color.Color `json:"color,omitempty"`
+	Count  int         `json:"count,omitempty"`
+	Cards  []game.Card `json:"cards,omitempty"`
+}
+
+func (dto PatchDTO) Serialize() []byte {
+	return Serialize(
+		dto, "patch")
+}
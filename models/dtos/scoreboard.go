@@ -0,0 +1,15 @@
+package dtos
+
+type ScoreboardDTO struct {
+	Round       int            `json:"round"`
+	RoundWinner string         `json:"round_winner"` // player id
+	Points      int            `json:"points"`
+	Scores      map[string]int `json:"scores"` // keyed by player id
+	ScoreTarget int            `json:"score_target"`
+	MatchOver   bool           `json:"match_over"`
+}
+
+func (dto ScoreboardDTO) Serialize() []byte {
+	return Serialize(
+		dto, "scoreboard")
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "uno/models/game"
+
+// SpectateDTO is the public view of a room sent to spectators in place of a
+// sync. It never holds anybody's cards.
+type SpectateDTO struct {
+	Game GameState `json:"game"`
+	Room RoomState `json:"room"`
+}
+
+func (dto SpectateDTO) Serialize() []byte {
+	return Serialize(
+		dto, "spectate")
+}
+
+// RevealDTO shows spectators who asked for the full reveal every hand as it
+// was at state Version, once the room's reveal delay has passed. Hands are
+// keyed by player id.
+type RevealDTO struct {
+	Version int                    `json:"version"`
+	Hands   map[string][]game.Card `json:"hands"`
+}
+
+func (dto RevealDTO) Serialize() []byte {
+	return Serialize(
+		dto, "reveal")
+}
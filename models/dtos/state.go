@@ -6,22 +6,49 @@ import (
 )
 
 type SyncDTO struct {
-	Player game.Player `json:"player"`
-	Game   GameState   `json:"game"`
-	Room   RoomState   `json:"room"`
+	Player game.Player   `json:"player"`
+	Moves  LegalMovesDTO `json:"moves"`
+	Game   GameState     `json:"game"`
+	Room   RoomState     `json:"room"`
 }
 
 type GameState struct {
-	TopCard game.Card `json:"topcard"`
-	TopColor color.Color `json:"topcolor"`
-	Turn    string    `json:"turn"`
-	Reverse bool      `json:"reverse"`
+	Version     int             `json:"version"`
+	TopCard     game.Card       `json:"topcard"`
+	TopColor    color.Color     `json:"topcolor"`
+	Turn        string          `json:"turn"` // player id
+	Reverse     bool            `json:"reverse"`
+	PendingDraw int             `json:"pending_draw"`
+	Phase       string          `json:"phase"`
+	UnoCalled   map[string]bool `json:"uno_called"` // keyed by player id
+	Seats       []SeatState     `json:"seats"`
+	DrawPile    int             `json:"draw_pile"`
+	DiscardPile int             `json:"discard_pile"`
+	LastAction  *PatchEvent     `json:"last_action,omitempty"`
+
+	// Deadline is when the server plays the active player's turn, in
+	// milliseconds since the Unix epoch. It is left out while the turn is not
+	// timed. TimeBanks holds the time left in every player's bank in
+	// milliseconds, keyed by player id, when the room plays with one.
+	Deadline  int64            `json:"deadline,omitempty"`
+	TimeBanks map[string]int64 `json:"time_banks,omitempty"`
+}
+
+// SeatState is what everybody at the table can see of a player. Seats are
+// listed in seat order; Reverse tells which way the turn goes round.
+type SeatState struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Cards     int    `json:"cards"`
+	UnoCalled bool   `json:"uno_called"`
+	Connected bool   `json:"connected"`
+	Bot       bool   `json:"bot"`
 }
 
 type RoomState struct {
 	Players    []string `json:"players"`
-	RoomId     int           `json:"id"`
-	MaxPlayers int           `json:"max_players"`
+	RoomId     int      `json:"id"`
+	MaxPlayers int      `json:"max_players"`
 }
 
 func (dto SyncDTO) Serialize() []byte {
@@ -1,11 +1,15 @@
 package game
 
 import (
+	"strconv"
 	"uno/models/constants/color"
 	"uno/models/constants/rank"
 )
 
+// Card is one physical card. ID is unique within a deck and stays with the
+// card wherever it goes, so it can be used to refer to the card.
 type Card struct {
+	ID    int
 	Rank  rank.Rank   `validate:"required"`
 	Color color.Color `validate:"omitempty"`
 }
@@ -19,6 +23,18 @@ func (c Card) Type() string {
 	return "action-card"
 }
 
+// Points returns the value of the card when it is left in a hand at the end of a round.
+func (c Card) Points() int {
+	switch c.Type() {
+	case "number-card":
+		value, _ := strconv.Atoi(string(c.Rank))
+		return value
+	case "action-card-no-color":
+		return 50
+	}
+	return 20
+}
+
 func (c Card) LogCard() string {
 	return string(c.Rank) + " " + string(c.Color)
 }
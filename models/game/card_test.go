@@ -0,0 +1,29 @@
+package game
+
+import (
+	"testing"
+	"uno/models/constants/color"
+	"uno/models/constants/rank"
+)
+
+func TestCardPoints(t *testing.T) {
+	tests := []struct {
+		card Card
+		want int
+	}{
+		{Card{Rank: rank.ZERO, Color: color.RED}, 0},
+		{Card{Rank: rank.SEVEN, Color: color.GREEN}, 7},
+		{Card{Rank: rank.NINE, Color: color.BLUE}, 9},
+		{Card{Rank: rank.SKIP, Color: color.YELLOW}, 20},
+		{Card{Rank: rank.REVERSE, Color: color.RED}, 20},
+		{Card{Rank: rank.DRAW_2, Color: color.GREEN}, 20},
+		{Card{Rank: rank.WILD}, 50},
+		{Card{Rank: rank.DRAW_4}, 50},
+	}
+
+	for _, tt := range tests {
+		if got := tt.card.Points(); got != tt.want {
+			t.Errorf("%s scores %d points, want %d", tt.card.LogCard(), got, tt.want)
+		}
+	}
+}
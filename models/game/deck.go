@@ -2,7 +2,6 @@ package game
 
 import (
 	"math/rand"
-	"time"
 )
 
 type Deck struct {
@@ -18,9 +17,9 @@ func NewDeck() *Deck {
 	}
 }
 
-func (d *Deck) Shuffle() {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(d.Cards), func(i, j int) {
+// Shuffle puts the cards in a random order drawn from r.
+func (d *Deck) Shuffle(r *rand.Rand) {
+	r.Shuffle(len(d.Cards), func(i, j int) {
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
 	})
 }
@@ -40,6 +39,17 @@ func (d *Deck) RemoveCard(index int) Card {
 	return card
 }
 
+// IndexOf returns the position of the card with the given id, or -1 when the
+// deck does not hold it.
+func (d *Deck) IndexOf(id int) int {
+	for i, card := range d.Cards {
+		if card.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 func (d *Deck) NumberOfCards() int {
 	return len(d.Cards)
 }
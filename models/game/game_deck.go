@@ -2,24 +2,40 @@ package game
 
 import (
 	"errors"
+	"math/rand"
 	color "uno/models/constants/color"
 	rank "uno/models/constants/rank"
 )
 
+// DeckSize is the number of cards in a full UNO deck.
+const DeckSize = 108
+
 type GameDeck struct {
 	*Deck
+	rand *rand.Rand
 }
 
-func NewGameDeck() *GameDeck {
+// NewGameDeck returns a full deck shuffled with r. Every later shuffle of the
+// deck uses r as well, so a deck built from the same seed deals the same cards.
+func NewGameDeck(r *rand.Rand) *GameDeck {
 	gd := &GameDeck{
 		Deck: NewDeck(),
+		rand: r,
 	}
 	gd.initColoredCards()
 	gd.initNonColoredCards()
+	for i := range gd.Cards {
+		gd.Cards[i].ID = i + 1
+	}
 	gd.Shuffle()
 	return gd
 }
 
+// Shuffle shuffles the deck with its own random source.
+func (gd *GameDeck) Shuffle() {
+	gd.Deck.Shuffle(gd.rand)
+}
+
 func (gd *GameDeck) initColoredCards() {
 	for _, c := range color.ALLColors {
 		for _, r := range rank.NumberCards {
@@ -47,8 +63,13 @@ func (gd *GameDeck) initNonColoredCards() {
 	}
 }
 
+// Cut removes up to n cards from the top of the deck. When fewer than n cards
+// are left it returns all of them.
 func (gd *GameDeck) Cut(n int) []Card {
-	if n <= 0 || n > len(gd.Cards) {
+	if n > len(gd.Cards) {
+		n = len(gd.Cards)
+	}
+	if n <= 0 {
 		// If n is invalid, return an empty slice
 		return []Card{}
 	}
@@ -68,6 +89,21 @@ func (gd *GameDeck) GetStartCard() *Card {
 	return nil
 }
 
+// TurnUpStartCard removes the first card of the deck to start the discard
+// pile. A wild draw_4 is never used; it goes back into the deck, which is
+// shuffled again.
+func (gd *GameDeck) TurnUpStartCard() *Card {
+	if len(gd.Cards) == 0 {
+		return nil
+	}
+	for gd.Cards[0].Rank == rank.DRAW_4 {
+		gd.Shuffle()
+	}
+	card := gd.Cards[0]
+	gd.Cards = gd.Cards[1:]
+	return &card
+}
+
 func (gd *GameDeck) TopCard() (*Card, error) {
 	if len(gd.Cards) == 0 {
 		return nil, errors.New("game deck is empty")
@@ -3,9 +3,15 @@ package game
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// MaxNameLength is the longest player name accepted, in characters.
+const MaxNameLength = 20
+
 type Player struct {
+	ID   string // assigned by the server, unique within a room
 	Name string
 	*Deck
 	Drawn bool
@@ -13,18 +19,39 @@ type Player struct {
 
 func NewPlayer(name string) *Player {
 	player := &Player{
-		Name: name,
-		Deck: NewDeck(),
+		Name:  name,
+		Deck:  NewDeck(),
 		Drawn: false,
 	}
 	return player
 }
 
+// ValidatePlayerName trims the surrounding spaces off name and checks what is
+// left is a displayable name.
+func ValidatePlayerName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if !utf8.ValidString(name) {
+		return "", fmt.Errorf("player_name must be valid UTF-8")
+	}
+	if name == "" {
+		return "", fmt.Errorf("player_name must not be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("player_name must be at most %d characters", MaxNameLength)
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return "", fmt.Errorf("player_name must only contain printable characters")
+		}
+	}
+	return name, nil
+}
+
 func (player *Player) CardInHand() string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("%s has:\n", player.Name))
-	for i, c := range player.Deck.Cards {
-		sb.WriteString(fmt.Sprintf("Card %d:\t%s\n", i, c.LogCard()))
+	for _, c := range player.Deck.Cards {
+		sb.WriteString(fmt.Sprintf("Card %d:\t%s\n", c.ID, c.LogCard()))
 	}
 	return sb.String()
 }
@@ -34,19 +61,3 @@ func (player *Player) AddCards(cards []Card) {
 		player.AddCard(c)
 	}
 }
-
-func (p *Player) HasPlayableCard(topCard Card) bool {
-
-	for _, card := range p.Deck.Cards {
-		switch {
-		case card.Type() == "action-card" && card.Color == topCard.Color: // DRAW2 ,SKIP ,REVERSE don't have rank
-			return true
-		case card.Type() == "action-card-no-color":
-			return true
-		case card.Color == topCard.Color || card.Rank == topCard.Rank:
-			return true
-		}
-	}
-
-	return false
-}
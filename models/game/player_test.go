@@ -0,0 +1,36 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePlayerName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"Alice", "Alice", false},
+		{"  Bob  ", "Bob", false},
+		{"Zoë", "Zoë", false},
+		{strings.Repeat("é", MaxNameLength), strings.Repeat("é", MaxNameLength), false},
+		{strings.Repeat("a", MaxNameLength+1), "", true},
+		{"   ", "", true},
+		{"", "", true},
+		{"Al\nice", "", true},
+		{"Al\x00ice", "", true},
+		{"\xff", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ValidatePlayerName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidatePlayerName(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ValidatePlayerName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package game
+
+// DrawMode decides how many cards a player takes when they draw on their turn.
+type DrawMode string
+
+const (
+	// DrawOne draws a single card.
+	DrawOne DrawMode = "draw_one"
+	// DrawUntilPlayable keeps drawing until a playable card turns up.
+	DrawUntilPlayable DrawMode = "draw_until_playable"
+)
+
+// Rules holds the optional house rules a room is played with.
+type Rules struct {
+	// HandSize is the number of cards dealt to every player.
+	HandSize int `json:"hand_size"`
+
+	// Stacking lets a player answer a draw_2 with a draw_2 (or a draw_4 with a
+	// draw_4) so the penalty builds up for the next player.
+	Stacking bool `json:"stacking"`
+
+	// DrawMode and ForcedPlay decide what happens when a player draws. With
+	// ForcedPlay a playable drawn card has to be played right away.
+	DrawMode   DrawMode `json:"draw_mode"`
+	ForcedPlay bool     `json:"forced_play"`
+
+	// Match plays several rounds. The winner of a round scores the points left
+	// in the other players' hands and the first to reach ScoreTarget wins.
+	Match       bool `json:"match"`
+	ScoreTarget int  `json:"score_target"`
+
+	// SevenO makes a 7 swap hands with a chosen opponent and a 0 pass every
+	// hand to the next player in the direction of play.
+	SevenO bool `json:"seven_o"`
+
+	// JumpIn lets any player play a card identical to the top card out of turn.
+	JumpIn bool `json:"jump_in"`
+
+	// OpeningEffects starts the round on whatever card is turned up and applies
+	// its effect to the first player. Without it the round always starts on a
+	// number card.
+	OpeningEffects bool `json:"opening_effects"`
+}
+
+const (
+	// DefaultHandSize is the number of cards dealt under the official rules.
+	DefaultHandSize = 7
+	// DefaultScoreTarget is the score that wins a match under the official rules.
+	DefaultScoreTarget = 500
+)
+
+// WithDefaults returns a copy of the rules where every option left at its
+// zero value is replaced by the official default.
+func (r Rules) WithDefaults() Rules {
+	if r.HandSize <= 0 {
+		r.HandSize = DefaultHandSize
+	}
+	if r.DrawMode == "" {
+		r.DrawMode = DrawOne
+	}
+	if r.ScoreTarget <= 0 {
+		r.ScoreTarget = DefaultScoreTarget
+	}
+	return r
+}
@@ -0,0 +1,118 @@
+package game
+
+import "fmt"
+
+const (
+	MaxHandSize       = 20
+	MaxTurnTimer      = 600
+	MaxReconnectGrace = 600
+	MaxInactivity     = 600
+	MaxTimeBank       = 3600
+	MaxRevealDelay    = 3600
+
+	// DefaultReconnectGrace is how many seconds a room waits for a player
+	// who lost their connection.
+	DefaultReconnectGrace = 60
+
+	// DefaultInactivityTimeout is how many seconds a player may leave their
+	// turn untouched, and DefaultAFKTakeover how many turns in a row, before
+	// the server plays their whole seat.
+	DefaultInactivityTimeout = 60
+	DefaultAFKTakeover       = 3
+)
+
+// TurnPenalty decides the move the server makes for a player who ran out of
+// time.
+type TurnPenalty string
+
+const (
+	// PenaltyDrawAndPass draws a card and passes, unless the forced play rule
+	// makes the drawn card go down.
+	PenaltyDrawAndPass TurnPenalty = "draw_and_pass"
+	// PenaltyAutoPlay draws a card and plays it when it goes, like the
+	// inactivity timeout does.
+	PenaltyAutoPlay TurnPenalty = "auto_play"
+)
+
+// RoomSettings is the configuration a room is created with. The house rules
+// are embedded so they appear at the top level of the JSON object.
+type RoomSettings struct {
+	Rules
+
+	// TurnTimer is the number of seconds a player has to finish their turn.
+	// Zero means turns are not timed. TurnPenalty is the move the server
+	// makes once the time is up.
+	TurnTimer   int         `json:"turn_timer"`
+	TurnPenalty TurnPenalty `json:"turn_penalty"`
+
+	// TimeBank gives every player a total number of seconds for all of their
+	// turns, like a chess clock. A player whose bank is empty gets the turn
+	// penalty as soon as their turn starts. Zero disables the bank.
+	TimeBank int `json:"time_bank"`
+
+	// ReconnectGrace is the number of seconds the game is paused when a player
+	// loses their connection. Once it runs out the game goes on and the server
+	// plays the player's turns until they are back. Zero never pauses.
+	ReconnectGrace int `json:"reconnect_grace"`
+
+	// InactivityTimeout is the number of seconds a player may leave their turn
+	// untouched before the server makes a conservative move for them. Zero
+	// waits forever. After AFKTakeover timeouts in a row the server plays the
+	// seat until the player moves or reconnects.
+	InactivityTimeout int `json:"inactivity_timeout"`
+	AFKTakeover       int `json:"afk_takeover"`
+
+	// RevealDelay is the number of seconds after which spectators who asked
+	// for the full reveal see every hand. Zero never shows hands to anybody.
+	RevealDelay int `json:"reveal_delay"`
+}
+
+// DefaultRoomSettings returns the settings of a room played by the official rules.
+func DefaultRoomSettings() RoomSettings {
+	return RoomSettings{
+		Rules:             Rules{}.WithDefaults(),
+		TurnPenalty:       PenaltyDrawAndPass,
+		ReconnectGrace:    DefaultReconnectGrace,
+		InactivityTimeout: DefaultInactivityTimeout,
+		AFKTakeover:       DefaultAFKTakeover,
+	}
+}
+
+// Validate checks every option is within its allowed range.
+func (s RoomSettings) Validate() error {
+	if s.HandSize < 1 || s.HandSize > MaxHandSize {
+		return fmt.Errorf("hand_size must be between 1 and %d", MaxHandSize)
+	}
+	switch s.DrawMode {
+	case DrawOne, DrawUntilPlayable:
+	default:
+		return fmt.Errorf("invalid draw_mode: %s", s.DrawMode)
+	}
+	if s.ScoreTarget < 1 {
+		return fmt.Errorf("score_target must be positive")
+	}
+	if s.TurnTimer < 0 || s.TurnTimer > MaxTurnTimer {
+		return fmt.Errorf("turn_timer must be between 0 and %d seconds", MaxTurnTimer)
+	}
+	switch s.TurnPenalty {
+	case PenaltyDrawAndPass, PenaltyAutoPlay:
+	default:
+		return fmt.Errorf("invalid turn_penalty: %s", s.TurnPenalty)
+	}
+	if s.TimeBank < 0 || s.TimeBank > MaxTimeBank {
+		return fmt.Errorf("time_bank must be between 0 and %d seconds", MaxTimeBank)
+	}
+	if s.ReconnectGrace < 0 || s.ReconnectGrace > MaxReconnectGrace {
+		return fmt.Errorf("reconnect_grace must be between 0 and %d seconds", MaxReconnectGrace)
+	}
+	if s.InactivityTimeout < 0 || s.InactivityTimeout > MaxInactivity {
+		return fmt.Errorf("inactivity_timeout must be between 0 and %d seconds", MaxInactivity)
+	}
+	if s.AFKTakeover < 1 {
+		return fmt.Errorf("afk_takeover must be positive")
+	}
+	if s.RevealDelay < 0 || s.RevealDelay > MaxRevealDelay {
+		return fmt.Errorf("reveal_delay must be between 0 and %d seconds", MaxRevealDelay)
+	}
+	return nil
+}